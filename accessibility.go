@@ -0,0 +1,96 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/unison/enums/role"
+)
+
+var (
+	// ReducedMotion causes animations to be skipped, jumping directly to their final state. This includes the caret
+	// blink in fields, collapsible panel expansion, tab reordering within docks, the motion of spinners and
+	// indeterminate progress bars, the frames of an AnimatedDrawable, and marching ants. It will be set to true at
+	// startup if the platform reports that the user prefers reduced motion.
+	ReducedMotion bool
+	// HighContrast causes the colors derived from the theme colors to be pushed further apart and the edges of controls
+	// to be drawn thicker. It will be set to true at startup if the platform reports that the user prefers increased
+	// contrast. If changed after startup, call ThemeChanged() to update the display.
+	HighContrast bool
+	// AccessibilityFocusCallback, if set, is called whenever a panel gains the keyboard focus, with the information
+	// that should be reported for it. This is the point at which a bridge to the platform's accessibility API
+	// (NSAccessibility, UI Automation or AT-SPI) can announce the newly focused element.
+	AccessibilityFocusCallback func(panel *Panel, info AccessibilityInfo)
+)
+
+// AccessibilityState holds flags describing the state of a panel, as reported to assistive technologies.
+type AccessibilityState uint16
+
+// Possible AccessibilityState flags.
+const (
+	AccessibilityDisabled AccessibilityState = 1 << iota
+	AccessibilityFocused
+	AccessibilityChecked
+	AccessibilityMixed
+	AccessibilitySelected
+	AccessibilityExpanded
+	AccessibilityReadOnly
+	AccessibilityInvalid
+)
+
+// AccessibilityInfo holds the information about a panel that is made available to assistive technologies.
+type AccessibilityInfo struct {
+	// Name is the label that identifies the panel, e.g. the title of a button.
+	Name string
+	// Value is the current value of the panel, e.g. the text of a field or the chosen item of a popup menu.
+	Value string
+	// SelectionStart and SelectionEnd hold the selected range of the Value, in runes, for text roles. When they are
+	// equal, they hold the caret position.
+	SelectionStart int
+	SelectionEnd   int
+	Role           role.Enum
+	State          AccessibilityState
+}
+
+// Accessibility returns the information that should be reported to assistive technologies for this panel. The
+// AccessibilityCallback is used if set. The disabled and focused state flags are always filled in from the panel.
+func (p *Panel) Accessibility() AccessibilityInfo {
+	var info AccessibilityInfo
+	if p.AccessibilityCallback != nil {
+		info = p.AccessibilityCallback()
+	}
+	if !p.Enabled() {
+		info.State |= AccessibilityDisabled
+	}
+	if p.Focused() {
+		info.State |= AccessibilityFocused
+	}
+	return info
+}
+
+func notifyAccessibilityFocus(panel *Panel) {
+	if AccessibilityFocusCallback != nil && panel != nil {
+		info := panel.Accessibility()
+		toolbox.Call(func() { AccessibilityFocusCallback(panel, info) })
+	}
+}
+
+// highContrastLightnessFactor is the amount the lightness adjustments of derived theme colors are scaled by when
+// HighContrast is enabled.
+const highContrastLightnessFactor = 2.5
+
+func initAccessibility() {
+	if platformPrefersReducedMotion() {
+		ReducedMotion = true
+	}
+	if platformPrefersHighContrast() {
+		HighContrast = true
+	}
+}
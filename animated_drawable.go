@@ -0,0 +1,140 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import "time"
+
+var _ Drawable = &AnimatedDrawable{}
+
+// AnimatedDrawable cycles through a series of frames at a given frame rate, marking its owner for redraw each time the
+// frame changes. The animation stops on its own if the owner is no longer in a valid window, so Start() should be
+// called once the owner has been placed into a window.
+type AnimatedDrawable struct {
+	Owner         Paneler
+	Frames        []Drawable
+	frameDuration time.Duration
+	index         int
+	generation    int
+	running       bool
+}
+
+// NewAnimatedDrawable creates a new AnimatedDrawable that will mark owner for redraw as it changes frames. The
+// animation is not started automatically.
+func NewAnimatedDrawable(owner Paneler, framesPerSecond float32, frames ...Drawable) *AnimatedDrawable {
+	d := &AnimatedDrawable{
+		Owner:  owner,
+		Frames: frames,
+	}
+	d.SetFrameRate(framesPerSecond)
+	return d
+}
+
+// FrameRate returns the number of frames per second.
+func (d *AnimatedDrawable) FrameRate() float32 {
+	return float32(time.Second) / float32(d.frameDuration)
+}
+
+// SetFrameRate sets the number of frames per second. Values <= 0 will be treated as 1.
+func (d *AnimatedDrawable) SetFrameRate(framesPerSecond float32) {
+	if framesPerSecond <= 0 {
+		framesPerSecond = 1
+	}
+	d.frameDuration = max(time.Duration(float32(time.Second)/framesPerSecond), time.Millisecond)
+}
+
+// Running returns true if the animation is currently running.
+func (d *AnimatedDrawable) Running() bool {
+	return d.running
+}
+
+// Start the animation. Does nothing if it is already running. When ReducedMotion is set, the animation is considered
+// running, but holds its current frame rather than advancing.
+func (d *AnimatedDrawable) Start() {
+	if !d.running {
+		d.running = true
+		d.generation++
+		if !ReducedMotion {
+			d.scheduleNextFrame()
+		}
+	}
+}
+
+// Stop the animation, leaving the current frame in place.
+func (d *AnimatedDrawable) Stop() {
+	if d.running {
+		d.running = false
+		d.generation++
+	}
+}
+
+// Reset the animation back to its first frame.
+func (d *AnimatedDrawable) Reset() {
+	d.index = 0
+	d.markOwnerForRedraw()
+}
+
+func (d *AnimatedDrawable) scheduleNextFrame() {
+	generation := d.generation
+	InvokeTaskAfter(func() { d.nextFrame(generation) }, d.frameDuration)
+}
+
+func (d *AnimatedDrawable) nextFrame(generation int) {
+	if !d.running || generation != d.generation || ReducedMotion {
+		return
+	}
+	if d.Owner == nil {
+		d.running = false
+		return
+	}
+	if w := d.Owner.AsPanel().Window(); w == nil || !w.IsValid() {
+		d.running = false
+		return
+	}
+	if len(d.Frames) != 0 {
+		d.index = (d.index + 1) % len(d.Frames)
+	}
+	d.markOwnerForRedraw()
+	d.scheduleNextFrame()
+}
+
+func (d *AnimatedDrawable) markOwnerForRedraw() {
+	if d.Owner != nil {
+		d.Owner.AsPanel().MarkForRedraw()
+	}
+}
+
+func (d *AnimatedDrawable) currentFrame() Drawable {
+	if len(d.Frames) == 0 {
+		return nil
+	}
+	if d.index >= len(d.Frames) {
+		d.index = 0
+	}
+	return d.Frames[d.index]
+}
+
+// LogicalSize implements Drawable. The largest width and height of all frames is returned, so that the space occupied
+// by the animation doesn't change as it runs.
+func (d *AnimatedDrawable) LogicalSize() Size {
+	var size Size
+	for _, frame := range d.Frames {
+		fs := frame.LogicalSize()
+		size.Width = max(size.Width, fs.Width)
+		size.Height = max(size.Height, fs.Height)
+	}
+	return size
+}
+
+// DrawInRect implements Drawable.
+func (d *AnimatedDrawable) DrawInRect(canvas *Canvas, rect Rect, sampling *SamplingOptions, paint *Paint) {
+	if frame := d.currentFrame(); frame != nil {
+		frame.DrawInRect(canvas, rect, sampling, paint)
+	}
+}
@@ -42,6 +42,7 @@ var (
 	currentThemeMode                  = thememode.Auto
 	needPlatformDarkModeUpdate        = true
 	platformDarkModeEnabled           bool
+	softwareRendering                 bool
 )
 
 type startupOption struct { // This exists just to prevent arbitrary functions from being passed to application startup.
@@ -127,6 +128,16 @@ func NoGlobalMenuBar() StartupOption {
 	}
 }
 
+// SoftwareRendering causes windows to be rasterized on the CPU rather than by the graphics hardware. This is slower,
+// but produces the same output regardless of the hardware in use, which is useful for comparing rendered output in
+// tests. See also NewSoftwareSurface.
+func SoftwareRendering() StartupOption {
+	return func(_ startupOption) error {
+		softwareRendering = true
+		return nil
+	}
+}
+
 // Start the application. This function does NOT return. While some calls may be safe to make, it should be assumed no
 // calls into unison can be made prior to Start() being called unless explicitly stated otherwise.
 func Start(options ...StartupOption) {
@@ -177,6 +188,7 @@ func processEvents() {
 
 func finishStartup() {
 	skiaColorspace = skia.ColorSpaceNewSRGB()
+	initAccessibility()
 	RebuildDynamicColors()
 	platformLateInit()
 	if startupFinishedCallback != nil {
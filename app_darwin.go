@@ -73,6 +73,14 @@ func platformIsDarkModeEnabled() bool {
 	return ns.IsDarkModeEnabled()
 }
 
+func platformPrefersReducedMotion() bool {
+	return ns.AccessibilityShouldReduceMotion()
+}
+
+func platformPrefersHighContrast() bool {
+	return ns.AccessibilityShouldIncreaseContrast()
+}
+
 func platformDoubleClickInterval() time.Duration {
 	return ns.DoubleClickInterval()
 }
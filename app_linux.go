@@ -34,6 +34,16 @@ func platformIsDarkModeEnabled() bool {
 	return false
 }
 
+func platformPrefersReducedMotion() bool {
+	// TODO: Need implementation
+	return false
+}
+
+func platformPrefersHighContrast() bool {
+	// TODO: Need implementation
+	return false
+}
+
 func platformDoubleClickInterval() time.Duration {
 	return 500 * time.Millisecond
 }
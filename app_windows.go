@@ -66,6 +66,14 @@ func platformIsDarkModeEnabled() bool {
 	return atomic.LoadUint32(&appUsesLightThemeValue) == 0
 }
 
+func platformPrefersReducedMotion() bool {
+	return !w32.ClientAreaAnimationEnabled()
+}
+
+func platformPrefersHighContrast() bool {
+	return w32.HighContrastEnabled()
+}
+
 func platformDoubleClickInterval() time.Duration {
 	return w32.GetDoubleClickTime()
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/role"
 	"github.com/richardwilkes/unison/enums/side"
 )
 
@@ -68,8 +69,8 @@ type Button struct {
 	Drawable      Drawable
 	Text          *Text
 	group         *Group
-	Panel
 	ButtonTheme
+	Panel
 	Pressed bool
 }
 
@@ -87,6 +88,7 @@ func NewButton() *Button {
 	b.MouseUpCallback = b.DefaultMouseUp
 	b.KeyDownCallback = b.DefaultKeyDown
 	b.UpdateCursorCallback = b.DefaultUpdateCursor
+	b.AccessibilityCallback = b.DefaultAccessibility
 	return b
 }
 
@@ -102,6 +104,21 @@ func NewSVGButton(svg *SVG) *Button {
 	return b
 }
 
+// DefaultAccessibility provides the default accessibility information.
+func (b *Button) DefaultAccessibility() AccessibilityInfo {
+	info := AccessibilityInfo{Role: role.Button}
+	if b.Text != nil {
+		info.Name = b.Text.String()
+	}
+	if info.Name == "" && b.Tooltip != nil {
+		info.Name = tooltipText(b.Tooltip)
+	}
+	if b.Sticky && b.Pressed {
+		info.State |= AccessibilityChecked
+	}
+	return info
+}
+
 // SetTitle sets the text of the button to the specified text. The theme's TextDecoration will be used, so any
 // changes you want to make to it should be done before calling this method. Alternatively, you can directly set the
 // .Text field.
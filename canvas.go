@@ -10,10 +10,13 @@
 package unison
 
 import (
+	"time"
+
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/enums/blendmode"
 	"github.com/richardwilkes/unison/enums/filltype"
 	"github.com/richardwilkes/unison/enums/filtermode"
+	"github.com/richardwilkes/unison/enums/paintstyle"
 	"github.com/richardwilkes/unison/enums/pathop"
 	"github.com/richardwilkes/unison/enums/pointmode"
 	"github.com/richardwilkes/unison/internal/skia"
@@ -80,6 +83,13 @@ func (c *Canvas) Rotate(degrees float32) {
 	skia.CanvasRotateRadians(c.canvas, degrees*xmath.DegreesToRadians)
 }
 
+// RotateAbout rotates the coordinate system about the point (px, py).
+func (c *Canvas) RotateAbout(degrees, px, py float32) {
+	c.Translate(px, py)
+	c.Rotate(degrees)
+	c.Translate(-px, -py)
+}
+
 // Skew the coordinate system. A positive value of sx skews the drawing right as y-axis values increase; a positive
 // value of sy skews the drawing down as x-axis values increase.
 func (c *Canvas) Skew(sx, sy float32) {
@@ -133,6 +143,29 @@ func (c *Canvas) DrawRect(rect Rect, paint *Paint) {
 	skia.CanvasDrawRect(c.canvas, rect, paint.paint)
 }
 
+// DrawMarchingAntsRect strokes the rectangle with a 4-4 dash pattern offset by phase, as is commonly used to indicate a
+// selection. Use MarchingAntsPhase to obtain a phase that advances over time. The paint's style and path effect are
+// restored before returning.
+func (c *Canvas) DrawMarchingAntsRect(rect Rect, phase float32, paint *Paint) {
+	style := paint.Style()
+	effect := paint.PathEffect()
+	paint.SetStyle(paintstyle.Stroke)
+	paint.SetPathEffect(NewDashPathEffect([]float32{4, 4}, phase))
+	c.DrawRect(rect, paint)
+	paint.SetPathEffect(effect)
+	paint.SetStyle(style)
+}
+
+// MarchingAntsPhase returns a phase for DrawMarchingAntsRect based on the current time, such that the dashes move one
+// full pattern length each period. Redrawing periodically with this phase animates the dashes. When ReducedMotion is
+// set, a constant phase is returned, so the dashes remain still.
+func MarchingAntsPhase(period time.Duration) float32 {
+	if period <= 0 || ReducedMotion {
+		return 0
+	}
+	return 8 * float32(time.Now().UnixNano()%int64(period)) / float32(period)
+}
+
 // DrawRoundedRect draws a rounded rectangle with Paint.
 func (c *Canvas) DrawRoundedRect(rect Rect, radiusX, radiusY float32, paint *Paint) {
 	skia.CanvasDrawRoundRect(c.canvas, rect, radiusX, radiusY, paint.paint)
@@ -178,6 +211,34 @@ func (c *Canvas) DrawImageNine(img *Image, centerRect, dstRect Rect, filter filt
 		paint.paintOrNil())
 }
 
+// DrawImageNinePatch draws an image stretched to fit into dstRect, keeping its corners at their logical size. Unlike
+// DrawImageNine, 'center' is in the image's logical coordinates, so images with a scale other than 1 keep corners that
+// match their logical size, and the filter mode of the sampling options is honored. 'center' divides the image into
+// nine sections: the corners are drawn unscaled (or scaled down proportionately if they don't fit within dstRect), the
+// top and bottom edges are stretched horizontally, the left and right edges are stretched vertically, and the center is
+// stretched in both directions. paint may be nil.
+func (c *Canvas) DrawImageNinePatch(img *Image, center, dstRect Rect, sampling *SamplingOptions, paint *Paint) {
+	if dstRect.Empty() {
+		return
+	}
+	if sampling == nil {
+		sampling = &defaultSampling
+	}
+	scale := img.Scale()
+	center = center.Intersect(Rect{Size: img.LogicalSize()})
+	center.X /= scale
+	center.Y /= scale
+	center.Width /= scale
+	center.Height /= scale
+	// Skia draws the fixed sections at their pixel size, so draw in a space where a pixel has the logical size
+	c.Save()
+	c.Translate(dstRect.X, dstRect.Y)
+	c.Scale(scale, scale)
+	skia.CanvasDrawImageNine(c.canvas, img.ref().contextImg(c.surface), center,
+		NewRect(0, 0, dstRect.Width/scale, dstRect.Height/scale), skia.FilterMode(sampling.FilterMode), paint.paintOrNil())
+	c.Restore()
+}
+
 // DrawColor fills the clip with the color.
 func (c *Canvas) DrawColor(color Color, mode blendmode.Enum) {
 	skia.CanvasDrawColor(c.canvas, skia.Color(color), skia.BlendMode(mode))
@@ -198,6 +259,14 @@ func (c *Canvas) DrawLine(sx, sy, ex, ey float32, paint *Paint) {
 	skia.CanvasDrawLine(c.canvas, sx, sy, ex, ey, paint.paint)
 }
 
+// DrawLines draws a separate line for each pair of points. If an odd number of points is provided, the last one is
+// ignored. This is more efficient than calling DrawLine repeatedly when drawing many lines.
+func (c *Canvas) DrawLines(pts []Point, paint *Paint) {
+	if len(pts) > 1 {
+		skia.CanvasDrawPoints(c.canvas, skia.PointMode(pointmode.Lines), pts[:len(pts)&^1], paint.paint)
+	}
+}
+
 // DrawPolygon draws a polygon.
 func (c *Canvas) DrawPolygon(poly Polygon, mode filltype.Enum, paint *Paint) {
 	path := NewPath()
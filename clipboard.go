@@ -11,10 +11,14 @@ package unison
 
 import (
 	"sync"
+	"time"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/richardwilkes/toolbox"
 )
 
+const clipboardPollInterval = 500 * time.Millisecond
+
 // GlobalClipboard holds the global clipboard.
 var GlobalClipboard = &Clipboard{}
 
@@ -30,8 +34,89 @@ type ClipboardData struct {
 // want to consider serializing and unserializing your data into bytes to pass it through the clipboard, to avoid
 // accidental mutations.
 type Clipboard struct {
-	data map[string]any
-	lock sync.RWMutex
+	data            map[string]any
+	changeCallbacks map[int]func()
+	lastText        string
+	lock            sync.RWMutex
+	localChanges    int
+	textChanges     int
+	nextCallbackID  int
+	lastSeenCount   int
+	polling         bool
+}
+
+// ChangeCount returns a number that increases whenever the clipboard content changes, whether by this application or
+// by another. Compare the value against one retrieved earlier to cheaply detect changes. On platforms that don't
+// provide a change count for the system clipboard, this falls back to comparing the clipboard text, which is no
+// cheaper than calling GetText().
+func (c *Clipboard) ChangeCount() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.changeCount()
+}
+
+// changeCount returns the current change count. The caller must hold the write lock.
+func (c *Clipboard) changeCount() int {
+	count, ok := platformClipboardChangeCount()
+	if !ok {
+		if text := glfw.GetClipboardString(); text != c.lastText {
+			c.lastText = text
+			c.textChanges++
+		}
+		count = c.textChanges
+	}
+	return count + c.localChanges
+}
+
+// RegisterChangeCallback registers a callback that will be called on the UI thread after the clipboard content
+// changes. The clipboard is checked periodically while at least one callback is registered. Call the returned function
+// to unregister the callback.
+func (c *Clipboard) RegisterChangeCallback(f func()) (unregister func()) {
+	c.lock.Lock()
+	if c.changeCallbacks == nil {
+		c.changeCallbacks = make(map[int]func())
+	}
+	c.nextCallbackID++
+	id := c.nextCallbackID
+	c.changeCallbacks[id] = f
+	startPolling := !c.polling
+	c.polling = true
+	c.lock.Unlock()
+	if startPolling {
+		InvokeTask(func() {
+			c.lock.Lock()
+			c.lastSeenCount = c.changeCount()
+			c.lock.Unlock()
+			InvokeTaskAfter(c.poll, clipboardPollInterval)
+		})
+	}
+	return func() {
+		c.lock.Lock()
+		delete(c.changeCallbacks, id)
+		c.lock.Unlock()
+	}
+}
+
+func (c *Clipboard) poll() {
+	c.lock.Lock()
+	if len(c.changeCallbacks) == 0 {
+		c.polling = false
+		c.lock.Unlock()
+		return
+	}
+	var callbacks []func()
+	if count := c.changeCount(); count != c.lastSeenCount {
+		c.lastSeenCount = count
+		callbacks = make([]func(), 0, len(c.changeCallbacks))
+		for _, f := range c.changeCallbacks {
+			callbacks = append(callbacks, f)
+		}
+	}
+	c.lock.Unlock()
+	for _, f := range callbacks {
+		toolbox.Call(f)
+	}
+	InvokeTaskAfter(c.poll, clipboardPollInterval)
 }
 
 // GetText returns text from the current clipboard data. This reads from the system clipboard.
@@ -44,6 +129,7 @@ func (c *Clipboard) SetText(str string) {
 	glfw.SetClipboardString(str)
 	c.lock.Lock()
 	c.data = nil
+	c.localChanges++
 	c.lock.Unlock()
 }
 
@@ -63,6 +149,7 @@ func (c *Clipboard) SetData(dataType string, data any) {
 	c.lock.Lock()
 	c.data = make(map[string]any)
 	c.data[dataType] = data
+	c.localChanges++
 	c.lock.Unlock()
 	if s, ok := data.(string); ok {
 		glfw.SetClipboardString(s)
@@ -86,6 +173,7 @@ func (c *Clipboard) SetMultipleData(pairs []ClipboardData) {
 			}
 		}
 	}
+	c.localChanges++
 	c.lock.Unlock()
 	glfw.SetClipboardString(str)
 }
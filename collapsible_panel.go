@@ -0,0 +1,219 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox"
+)
+
+// DefaultCollapsiblePanelTheme holds the default CollapsiblePanelTheme values for CollapsiblePanels. Modifying this
+// data will not alter existing CollapsiblePanels, but will alter any CollapsiblePanels created in the future.
+var DefaultCollapsiblePanelTheme = CollapsiblePanelTheme{
+	LabelTheme:    DefaultLabelTheme,
+	HeaderBorder:  NewEmptyBorder(Insets{Top: 2, Bottom: 2}),
+	Gap:           4,
+	TickSpeed:     time.Second / 60,
+	AnimationTime: 150 * time.Millisecond,
+}
+
+// CollapsiblePanelTheme holds theming data for a CollapsiblePanel.
+type CollapsiblePanelTheme struct {
+	HeaderBorder Border
+	LabelTheme
+	TickSpeed     time.Duration
+	AnimationTime time.Duration
+	Gap           float32
+}
+
+// CollapsiblePanel provides a panel with a clickable header that shows or hides its content. Changes to the expanded
+// state are animated, with the panel's preferred height tracking the animation so that parent layouts adjust smoothly.
+type CollapsiblePanel struct {
+	// ExpandedChangedCallback is called whenever the expanded state changes.
+	ExpandedChangedCallback func()
+	CollapsiblePanelTheme
+	animStart time.Time
+	header    *Label
+	content   Paneler
+	Panel
+	generation int
+	progress   float32
+	startFrom  float32
+	expanded   bool
+	animating  bool
+}
+
+// NewCollapsiblePanel creates a new collapsible panel with the given title and content. The panel starts out
+// collapsed.
+func NewCollapsiblePanel(title string, content Paneler) *CollapsiblePanel {
+	c := &CollapsiblePanel{
+		CollapsiblePanelTheme: DefaultCollapsiblePanelTheme,
+		content:               content,
+	}
+	c.Self = c
+	c.SetLayout(c)
+	c.header = NewLabel()
+	c.header.LabelTheme = c.LabelTheme
+	c.header.Gap = c.Gap
+	c.header.SetTitle(title)
+	c.header.SetBorder(c.HeaderBorder)
+	baseline := c.Font.Baseline()
+	c.header.Drawable = &collapsibleChevron{
+		owner: c,
+		DrawableSVG: DrawableSVG{
+			SVG:  ChevronRightSVG,
+			Size: Size{Width: baseline, Height: baseline},
+		},
+	}
+	c.header.MouseDownCallback = func(_ Point, button, _ int, _ Modifiers) bool {
+		if button != ButtonLeft || !c.Enabled() {
+			return false
+		}
+		c.SetExpanded(!c.expanded)
+		return true
+	}
+	c.header.UpdateCursorCallback = func(_ Point) *Cursor { return PointingCursor() }
+	c.AddChild(c.header)
+	content.AsPanel().Hidden = true
+	c.AddChild(content)
+	return c
+}
+
+// Title returns the title shown in the header.
+func (c *CollapsiblePanel) Title() string {
+	return c.header.String()
+}
+
+// SetTitle sets the title shown in the header.
+func (c *CollapsiblePanel) SetTitle(title string) {
+	c.header.SetTitle(title)
+	c.MarkForLayoutRecursivelyUpward()
+	c.MarkForRedraw()
+}
+
+// Content returns the content panel.
+func (c *CollapsiblePanel) Content() Paneler {
+	return c.content
+}
+
+// Expanded returns true if the panel is expanded, or is in the process of expanding.
+func (c *CollapsiblePanel) Expanded() bool {
+	return c.expanded
+}
+
+// SetExpanded sets the expanded state, animating the transition if the panel is within a valid window.
+func (c *CollapsiblePanel) SetExpanded(expanded bool) {
+	if c.expanded == expanded {
+		return
+	}
+	c.expanded = expanded
+	c.content.AsPanel().Hidden = false
+	c.generation++
+	if w := c.Window(); w != nil && w.IsValid() && c.AnimationTime > 0 && !ReducedMotion {
+		c.animating = true
+		c.animStart = time.Now()
+		c.startFrom = c.progress
+		c.scheduleTick()
+	} else {
+		c.finishAnimation()
+	}
+	c.MarkForLayoutRecursivelyUpward()
+	c.MarkForRedraw()
+	if c.ExpandedChangedCallback != nil {
+		toolbox.Call(c.ExpandedChangedCallback)
+	}
+}
+
+func (c *CollapsiblePanel) target() float32 {
+	if c.expanded {
+		return 1
+	}
+	return 0
+}
+
+func (c *CollapsiblePanel) finishAnimation() {
+	c.animating = false
+	c.progress = c.target()
+	c.content.AsPanel().Hidden = !c.expanded
+}
+
+func (c *CollapsiblePanel) scheduleTick() {
+	generation := c.generation
+	InvokeTaskAfter(func() { c.tick(generation) }, c.TickSpeed)
+}
+
+func (c *CollapsiblePanel) tick(generation int) {
+	if !c.animating || generation != c.generation {
+		return
+	}
+	if w := c.Window(); w == nil || !w.IsValid() {
+		c.finishAnimation()
+		return
+	}
+	// Scale the duration by the remaining distance, so that reversing part way through doesn't take the full time
+	target := c.target()
+	fraction := float32(time.Since(c.animStart)) / float32(c.AnimationTime)
+	if distance := target - c.startFrom; distance != 0 && fraction < max(distance, -distance) {
+		if distance > 0 {
+			c.progress = c.startFrom + fraction
+		} else {
+			c.progress = c.startFrom - fraction
+		}
+		c.scheduleTick()
+	} else {
+		c.finishAnimation()
+	}
+	c.MarkForLayoutRecursivelyUpward()
+	c.MarkForRedraw()
+}
+
+// LayoutSizes implements Layout.
+func (c *CollapsiblePanel) LayoutSizes(_ *Panel, hint Size) (minSize, prefSize, maxSize Size) {
+	_, prefSize, _ = c.header.Sizes(Size{Width: hint.Width})
+	if c.progress > 0 {
+		_, contentSize, _ := c.content.AsPanel().Sizes(Size{Width: hint.Width})
+		prefSize.Width = max(prefSize.Width, contentSize.Width)
+		prefSize.Height += contentSize.Height * c.progress
+	}
+	if b := c.Border(); b != nil {
+		prefSize = prefSize.Add(b.Insets().Size())
+	}
+	prefSize = prefSize.Ceil()
+	return prefSize, prefSize, Size{Width: DefaultMaxSize, Height: prefSize.Height}
+}
+
+// PerformLayout implements Layout.
+func (c *CollapsiblePanel) PerformLayout(_ *Panel) {
+	r := c.ContentRect(false)
+	_, headerSize, _ := c.header.Sizes(Size{Width: r.Width})
+	c.header.SetFrameRect(Rect{Point: r.Point, Size: Size{Width: r.Width, Height: headerSize.Height}})
+	// The content is always given its full preferred height; while animating, the portion beyond our own bounds is
+	// clipped away.
+	content := c.content.AsPanel()
+	_, contentSize, _ := content.Sizes(Size{Width: r.Width})
+	content.SetFrameRect(NewRect(r.X, r.Y+headerSize.Height, r.Width, contentSize.Height))
+}
+
+// collapsibleChevron draws the chevron for a CollapsiblePanel header, rotated according to the expansion progress.
+type collapsibleChevron struct {
+	owner *CollapsiblePanel
+	DrawableSVG
+}
+
+func (d *collapsibleChevron) DrawInRect(canvas *Canvas, rect Rect, opts *SamplingOptions, paint *Paint) {
+	canvas.Save()
+	center := rect.Center()
+	canvas.Translate(center.X, center.Y)
+	canvas.Rotate(90 * d.owner.progress)
+	canvas.Translate(-center.X, -center.Y)
+	d.DrawableSVG.DrawInRect(canvas, rect, opts, paint)
+	canvas.Restore()
+}
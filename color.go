@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -22,9 +23,10 @@ import (
 
 var (
 	// ErrColorDecode is the sentinel error returned by the ColorDecode function on failure.
-	ErrColorDecode               = errors.New("invalid color string")
-	nameToColor                  = make(map[string]Color)
-	colorToName                  = make(map[Color]string)
+	ErrColorDecode = errors.New("invalid color string")
+	nameToColor    = make(map[string]Color)
+	colorToName    = make(map[Color]string)
+	colorNames     []string
 	_              ColorProvider = Color(0)
 )
 
@@ -999,4 +1001,11 @@ func init() {
 func registerColor(name string, color Color) {
 	nameToColor[strings.ToLower(name)] = color
 	colorToName[color] = name
+	colorNames = append(colorNames, name)
+}
+
+// ColorNames returns the names of the predefined colors that ColorDecode understands, in the order they were
+// registered, which is alphabetical.
+func ColorNames() []string {
+	return slices.Clone(colorNames)
 }
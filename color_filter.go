@@ -126,3 +126,51 @@ func Alpha50Filter() *ColorFilter {
 	}
 	return alpha50Filter
 }
+
+var protanopiaFilter *ColorFilter
+
+// ProtanopiaFilter returns a ColorFilter that simulates protanopia (the absence of red cones). The matrix is from
+// Machado, Oliveira & Fernandes (2009), at full severity.
+func ProtanopiaFilter() *ColorFilter {
+	if protanopiaFilter == nil {
+		protanopiaFilter = NewMatrixColorFilter([]float32{
+			0.152286, 1.052583, -0.204868, 0, 0,
+			0.114503, 0.786281, 0.099216, 0, 0,
+			-0.003882, -0.048116, 1.051998, 0, 0,
+			0, 0, 0, 1, 0,
+		})
+	}
+	return protanopiaFilter
+}
+
+var deuteranopiaFilter *ColorFilter
+
+// DeuteranopiaFilter returns a ColorFilter that simulates deuteranopia (the absence of green cones). The matrix is
+// from Machado, Oliveira & Fernandes (2009), at full severity.
+func DeuteranopiaFilter() *ColorFilter {
+	if deuteranopiaFilter == nil {
+		deuteranopiaFilter = NewMatrixColorFilter([]float32{
+			0.367322, 0.860646, -0.227968, 0, 0,
+			0.280085, 0.672501, 0.047413, 0, 0,
+			-0.011820, 0.042940, 0.968881, 0, 0,
+			0, 0, 0, 1, 0,
+		})
+	}
+	return deuteranopiaFilter
+}
+
+var tritanopiaFilter *ColorFilter
+
+// TritanopiaFilter returns a ColorFilter that simulates tritanopia (the absence of blue cones). The matrix is from
+// Machado, Oliveira & Fernandes (2009), at full severity.
+func TritanopiaFilter() *ColorFilter {
+	if tritanopiaFilter == nil {
+		tritanopiaFilter = NewMatrixColorFilter([]float32{
+			1.255528, -0.076749, -0.178779, 0, 0,
+			-0.078411, 0.930809, 0.147602, 0, 0,
+			0.004733, 0.691367, 0.303900, 0, 0,
+			0, 0, 0, 1, 0,
+		})
+	}
+	return tritanopiaFilter
+}
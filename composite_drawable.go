@@ -0,0 +1,78 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import "github.com/richardwilkes/unison/enums/align"
+
+var _ Drawable = &CompositeDrawable{}
+
+// DrawableOverlay holds a Drawable to be drawn on top of the base of a CompositeDrawable, such as a badge or status
+// dot. HAlign and VAlign determine the edge or center of the base the overlay is anchored to, while Offset is applied
+// afterward, in the base's logical coordinates. Scale adjusts the overlay's logical size; a value of 0 is treated as 1.
+type DrawableOverlay struct {
+	Drawable Drawable
+	Offset   Point
+	Scale    float32
+	HAlign   align.Enum
+	VAlign   align.Enum
+}
+
+// CompositeDrawable draws a base Drawable followed by zero or more overlays positioned relative to it.
+type CompositeDrawable struct {
+	Base     Drawable
+	Overlays []DrawableOverlay
+}
+
+// LogicalSize implements Drawable.
+func (d *CompositeDrawable) LogicalSize() Size {
+	if d.Base == nil {
+		return Size{}
+	}
+	return d.Base.LogicalSize()
+}
+
+// DrawInRect implements Drawable.
+func (d *CompositeDrawable) DrawInRect(canvas *Canvas, rect Rect, sampling *SamplingOptions, paint *Paint) {
+	if d.Base == nil {
+		return
+	}
+	d.Base.DrawInRect(canvas, rect, sampling, paint)
+	sx := float32(1)
+	sy := float32(1)
+	if size := d.Base.LogicalSize(); size.Width > 0 && size.Height > 0 {
+		sx = rect.Width / size.Width
+		sy = rect.Height / size.Height
+	}
+	for _, overlay := range d.Overlays {
+		if overlay.Drawable == nil {
+			continue
+		}
+		scale := overlay.Scale
+		if scale == 0 {
+			scale = 1
+		}
+		size := overlay.Drawable.LogicalSize()
+		r := Rect{Size: Size{Width: size.Width * scale * sx, Height: size.Height * scale * sy}}
+		r.X = rect.X + alignOverlay(overlay.HAlign, rect.Width, r.Width) + overlay.Offset.X*sx
+		r.Y = rect.Y + alignOverlay(overlay.VAlign, rect.Height, r.Height) + overlay.Offset.Y*sy
+		overlay.Drawable.DrawInRect(canvas, r, sampling, paint)
+	}
+}
+
+func alignOverlay(alignment align.Enum, available, size float32) float32 {
+	switch alignment {
+	case align.Middle, align.Fill:
+		return (available - size) / 2
+	case align.End:
+		return available - size
+	default:
+		return 0
+	}
+}
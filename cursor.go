@@ -11,9 +11,12 @@ package unison
 
 import (
 	"image"
+	"runtime"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison/enums/paintstyle"
 	"golang.org/x/image/draw"
 )
 
@@ -108,3 +111,39 @@ func NewCursor(img *Image, hotSpot Point) *Cursor {
 
 	return glfw.CreateCursor(nrgba, int(hotSpot.X), int(hotSpot.Y))
 }
+
+// NewCursorFromSVG creates a new custom cursor by rasterizing an SVG at the given logical size, using the content scale
+// of the primary display so that the cursor is crisp on high resolution displays. The SVG is filled with the provided
+// ink, or black if ink is nil. The hot spot is in the same logical coordinates as the size.
+func NewCursorFromSVG(svg *SVG, size Size, ink Ink, hotSpot Point) *Cursor {
+	if ink == nil {
+		ink = Black
+	}
+	// glfw treats the pixels of a cursor image as points on macOS, so the image must be at its logical size there
+	scale := float32(1)
+	if runtime.GOOS != toolbox.MacOS {
+		if display := PrimaryDisplay(); display != nil {
+			scale = max(display.ScaleX, display.ScaleY, 1)
+		}
+	}
+	s, err := NewSoftwareSurface(size.Ceil(), scale)
+	if err != nil {
+		errs.Log(err)
+		return ArrowCursor()
+	}
+	defer s.Dispose()
+	canvas := s.Canvas()
+	r := Rect{Size: size.Ceil()}
+	(&DrawableSVG{SVG: svg, Size: r.Size}).DrawInRect(canvas, r, nil, ink.Paint(canvas, r, paintstyle.Fill))
+	var img *Image
+	if img, err = s.snapshotImage(1); err != nil {
+		errs.Log(err)
+		return ArrowCursor()
+	}
+	var nrgba *image.NRGBA
+	if nrgba, err = img.ToNRGBA(); err != nil {
+		errs.Log(err)
+		return ArrowCursor()
+	}
+	return glfw.CreateCursor(nrgba, int(hotSpot.X*scale), int(hotSpot.Y*scale))
+}
@@ -213,13 +213,7 @@ func (d *Dock) DefaultDrawOver(gc *Canvas, dirty Rect) {
 			r.X += r.Width - half
 			r.Width = half
 		}
-		paint := d.DropAreaInk.Paint(gc, r, paintstyle.Fill)
-		paint.SetColorFilter(Alpha30Filter())
-		gc.DrawRect(r, paint)
-		r = r.Inset(NewUniformInsets(1))
-		p := d.DropAreaInk.Paint(gc, r, paintstyle.Stroke)
-		p.SetStrokeWidth(2)
-		gc.DrawRect(r, p)
+		DrawDropIndicator(gc, r, d.DropAreaInk)
 	}
 }
 
@@ -236,6 +236,21 @@ func (d *DockContainer) Close(dockable Dockable) {
 	}
 }
 
+// MinimumTabWidth returns the default minimum width tabs may be shrunk to before being moved into the overflow area.
+func (d *DockContainer) MinimumTabWidth() float32 {
+	return d.header.MinimumTabWidth
+}
+
+// SetMinimumTabWidth sets the default minimum width tabs may be shrunk to before being moved into the overflow area.
+// Dockables that implement TabMinimumWidthProvider may override this on a per-tab basis.
+func (d *DockContainer) SetMinimumTabWidth(width float32) {
+	width = max(width, 0)
+	if d.header.MinimumTabWidth != width {
+		d.header.MinimumTabWidth = width
+		d.header.MarkForLayoutAndRedraw()
+	}
+}
+
 // PreferredSize implements DockLayoutNode.
 func (d *DockContainer) PreferredSize() Size {
 	_, pref, _ := d.LayoutSizes(d.AsPanel(), Size{})
@@ -11,13 +11,20 @@ package unison
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 )
 
 var _ Layout = &dockHeader{}
 
+const (
+	dockTabSlideInterval = time.Second / 60
+	dockTabSlideFactor   = 0.35
+)
+
 // DefaultDockHeaderTheme holds the default DockHeaderTheme values for DockHeaders. Modifying this data will not alter
 // existing DockHeaders, but will alter any DockHeaders created in the future.
 var DefaultDockHeaderTheme = DockHeaderTheme{
@@ -46,9 +53,12 @@ type dockHeader struct {
 	owner                 *DockContainer
 	overflowButton        *Button
 	maximizeRestoreButton *Button
+	slideOffsets          map[*dockTab]float32
 	DockHeaderTheme
 	Panel
 	dragInsertIndex int
+	dragRoom        float32
+	sliding         bool
 }
 
 func newDockHeader(dc *DockContainer) *dockHeader {
@@ -57,6 +67,7 @@ func newDockHeader(dc *DockContainer) *dockHeader {
 		owner:                 dc,
 		overflowButton:        createDockHeaderButton(),
 		maximizeRestoreButton: createDockHeaderButton(),
+		slideOffsets:          make(map[*dockTab]float32),
 		dragInsertIndex:       -1,
 	}
 	d.Self = d
@@ -82,13 +93,16 @@ func (d *dockHeader) DefaultDraw(gc *Canvas, rect Rect) {
 		r := d.ContentRect(false)
 		r.Width = d.TabInsertSize
 		tabs, _ := d.partition()
-		switch {
-		case d.dragInsertIndex < len(tabs):
-			r.X = tabs[d.dragInsertIndex].FrameRect().X - ((d.TabGap-d.TabInsertSize)/2 + d.TabInsertSize + 1)
-		default:
-			r.X = tabs[len(tabs)-1].FrameRect().Right()
+		left := r.X
+		if d.dragInsertIndex > 0 {
+			left = tabs[min(d.dragInsertIndex, len(tabs))-1].FrameRect().Right()
+		}
+		right := left + d.TabGap
+		if d.dragInsertIndex < len(tabs) {
+			right = tabs[d.dragInsertIndex].FrameRect().X
 		}
-		gc.DrawRect(r, d.DropAreaInk.Paint(gc, rect, paintstyle.Fill))
+		r.X = (left + right - d.TabInsertSize) / 2
+		gc.FillRectWithInk(r, d.DropAreaInk)
 	}
 }
 
@@ -97,28 +111,48 @@ func (d *dockHeader) DefaultDataDragOver(where Point, data map[string]any) bool
 }
 
 func (d *dockHeader) dragOver(where Point, data map[string]any) Dockable {
-	d.dragInsertIndex = -1
+	insertIndex := -1
 	if dockable := DockableFromDragData(d.owner.Dock.DragKey, data); dockable != nil {
 		tabs, _ := d.partition()
-		d.dragInsertIndex = len(tabs)
+		insertIndex = len(tabs)
+		d.dragRoom = d.MinimumTabWidth + d.TabGap
+		for _, one := range tabs {
+			if one.dockable == dockable {
+				d.dragRoom = one.FrameRect().Width + d.TabGap
+				break
+			}
+		}
 		for i, one := range tabs {
+			// Use the position the tab would have without any slide offset applied, so that tabs moving out of the
+			// way don't cause the insertion point to jitter.
 			r := one.FrameRect()
+			r.X -= d.slideOffsets[one]
 			if where.X < r.CenterX() {
-				d.dragInsertIndex = i
+				insertIndex = i
 				break
 			}
 			if where.X < r.Right() {
-				d.dragInsertIndex = i + 1
+				insertIndex = i + 1
 				break
 			}
 		}
+		d.setDragInsertIndex(insertIndex)
 		return dockable
 	}
+	d.setDragInsertIndex(insertIndex)
 	return nil
 }
 
+func (d *dockHeader) setDragInsertIndex(index int) {
+	if d.dragInsertIndex != index {
+		d.dragInsertIndex = index
+		d.startSliding()
+		d.MarkForRedraw()
+	}
+}
+
 func (d *dockHeader) DefaultDataDragExit() {
-	d.dragInsertIndex = -1
+	d.setDragInsertIndex(-1)
 }
 
 func (d *dockHeader) DefaultDataDrop(where Point, data map[string]any) {
@@ -126,6 +160,53 @@ func (d *dockHeader) DefaultDataDrop(where Point, data map[string]any) {
 		d.owner.Stack(dockable, d.dragInsertIndex)
 	}
 	d.dragInsertIndex = -1
+	clear(d.slideOffsets)
+	d.MarkForLayoutAndRedraw()
+}
+
+// slideTarget returns the offset the tab at the given index should slide to in order to make room for the tab being
+// dragged.
+func (d *dockHeader) slideTarget(index int) float32 {
+	if d.dragInsertIndex >= 0 && index >= d.dragInsertIndex {
+		return d.dragRoom
+	}
+	return 0
+}
+
+func (d *dockHeader) startSliding() {
+	if !d.sliding {
+		d.sliding = true
+		InvokeTaskAfter(d.slide, dockTabSlideInterval)
+	}
+}
+
+func (d *dockHeader) slide() {
+	d.sliding = false
+	if w := d.Window(); w == nil || !w.IsValid() {
+		clear(d.slideOffsets)
+		return
+	}
+	tabs, _ := d.partition()
+	moving := false
+	for i, tab := range tabs {
+		current := d.slideOffsets[tab]
+		target := d.slideTarget(i)
+		delta := target - current
+		if ReducedMotion || xmath.Abs(delta) <= 1 {
+			if target == 0 {
+				delete(d.slideOffsets, tab)
+			} else {
+				d.slideOffsets[tab] = target
+			}
+			continue
+		}
+		d.slideOffsets[tab] = current + delta*dockTabSlideFactor
+		moving = true
+	}
+	d.MarkForLayoutAndRedraw()
+	if moving {
+		d.startSliding()
+	}
 }
 
 func (d *dockHeader) updateTitle(index int) {
@@ -163,7 +244,7 @@ func (d *dockHeader) LayoutSizes(target *Panel, _ Size) (minSize, prefSize, maxS
 	tabs, buttons := d.partition()
 	for i, dt := range tabs {
 		_, size, _ := dt.Sizes(Size{})
-		prefSize.Width += max(size.Width, d.MinimumTabWidth)
+		prefSize.Width += max(size.Width, d.minimumTabWidth(dt))
 		prefSize.Height = max(prefSize.Height, size.Height)
 		if i == 0 {
 			minSize.Width += size.Width
@@ -189,14 +270,26 @@ func (d *dockHeader) LayoutSizes(target *Panel, _ Size) (minSize, prefSize, maxS
 	return minSize, prefSize, MaxSize(prefSize)
 }
 
+// minimumTabWidth returns the minimum width the tab may be shrunk to.
+func (d *dockHeader) minimumTabWidth(dt *dockTab) float32 {
+	if provider, ok := dt.dockable.(TabMinimumWidthProvider); ok {
+		if width := provider.MinimumTabWidth(); width > 0 {
+			return width
+		}
+	}
+	return d.MinimumTabWidth
+}
+
 func (d *dockHeader) PerformLayout(_ *Panel) {
 	contentRect := d.ContentRect(false)
 	tabs, buttons := d.partition()
 	tabSizes := make([]Size, len(tabs))
+	minWidths := make([]float32, len(tabs))
 	extra := contentRect.Width
 	for i, dt := range tabs {
 		_, tabSizes[i], _ = dt.Sizes(Size{})
-		tabSizes[i].Width = max(tabSizes[i].Width, d.MinimumTabWidth)
+		minWidths[i] = d.minimumTabWidth(dt)
+		tabSizes[i].Width = max(tabSizes[i].Width, minWidths[i])
 		extra -= tabSizes[i].Width
 	}
 	buttonSizes := make([]Size, len(buttons))
@@ -220,20 +313,20 @@ func (d *dockHeader) PerformLayout(_ *Panel) {
 			fatTabs := 0
 			found = false
 			for i := range tabs {
-				if i != current && tabSizes[i].Width > d.MinimumTabWidth {
+				if i != current && tabSizes[i].Width > minWidths[i] {
 					fatTabs++
 				}
 			}
 			if fatTabs > 0 {
 				perTab := max(remaining/float32(fatTabs), 1)
 				for i := range tabs {
-					if i != current && tabSizes[i].Width > d.MinimumTabWidth {
+					if i != current && tabSizes[i].Width > minWidths[i] {
 						found = true
 						remaining -= perTab
 						tabSizes[i].Width -= perTab
-						if tabSizes[i].Width < d.MinimumTabWidth {
-							remaining += d.MinimumTabWidth - tabSizes[i].Width
-							tabSizes[i].Width = d.MinimumTabWidth
+						if tabSizes[i].Width < minWidths[i] {
+							remaining += minWidths[i] - tabSizes[i].Width
+							tabSizes[i].Width = minWidths[i]
 						}
 					}
 					if remaining <= 0 {
@@ -260,7 +353,7 @@ func (d *dockHeader) PerformLayout(_ *Panel) {
 			}
 			if remaining > 0 {
 				// STILL not small enough... reduce the size of the current tab, too
-				tabSizes[current].Width = max(tabSizes[current].Width-remaining, d.MinimumTabWidth)
+				tabSizes[current].Width = max(tabSizes[current].Width-remaining, minWidths[current])
 				remaining = 0
 			}
 			extra = -remaining
@@ -275,7 +368,7 @@ func (d *dockHeader) PerformLayout(_ *Panel) {
 		} else {
 			dt.Hidden = false
 			dt.SetFrameRect(Rect{
-				Point: Point{X: x, Y: contentRect.Y + (contentRect.Height-tabSizes[i].Height)/2},
+				Point: Point{X: x + d.slideOffsets[dt], Y: contentRect.Y + (contentRect.Height-tabSizes[i].Height)/2},
 				Size:  tabSizes[i],
 			}.Align())
 			x += tabSizes[i].Width + d.TabGap
@@ -299,6 +392,7 @@ func (d *dockHeader) PerformLayout(_ *Panel) {
 func (d *dockHeader) close(dockable Dockable) {
 	for i, c := range d.Children() {
 		if dt, ok := c.Self.(*dockTab); ok && dockable == dt.dockable {
+			delete(d.slideOffsets, dt)
 			d.RemoveChildAtIndex(i)
 			break
 		}
@@ -338,9 +432,14 @@ func (d *dockHeader) handleOverflowPopup() {
 	defer m.Dispose()
 	for i, tab := range tabs {
 		if tab.Hidden {
-			m.InsertItem(-1, m.Factory().NewItem(PopupMenuTemporaryBaseID+i+1, tab.dockable.Title(), KeyBinding{}, nil, func(item MenuItem) {
-				d.owner.SetCurrentDockable(tabs[item.ID()-(PopupMenuTemporaryBaseID+1)].dockable)
-			}))
+			item := m.Factory().NewItem(PopupMenuTemporaryBaseID+i+1, tab.dockable.Title(), KeyBinding{}, nil,
+				func(item MenuItem) {
+					d.owner.SetCurrentDockable(tabs[item.ID()-(PopupMenuTemporaryBaseID+1)].dockable)
+				})
+			if setter, ok := item.(MenuItemIconSetter); ok {
+				setter.SetIcon(tab.tabIcon())
+			}
+			m.InsertItem(-1, item)
 		}
 	}
 	m.Popup(d.overflowButton.RectToRoot(d.overflowButton.ContentRect(true)), 0)
@@ -10,8 +10,6 @@
 package unison
 
 import (
-	"strings"
-
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/paintstyle"
@@ -25,6 +23,13 @@ type TabCloser interface {
 	AttemptClose() bool
 }
 
+// TabMinimumWidthProvider defines the methods that may be implemented by a Dockable to override the minimum width its
+// tab may be shrunk to before it is moved into the overflow area.
+type TabMinimumWidthProvider interface {
+	// MinimumTabWidth returns the minimum width of the tab. A value <= 0 will use the dock header's minimum.
+	MinimumTabWidth() float32
+}
+
 // DefaultDockTabTheme holds the default DockTabTheme values for DockTabs. Modifying this data will not alter existing
 // DockTabs, but will alter any DockTabs created in the future.
 var DefaultDockTabTheme = DockTabTheme{
@@ -35,6 +40,7 @@ var DefaultDockTabTheme = DockTabTheme{
 	OnTabFocusedInk: ThemeOnFocus,
 	TabCurrentInk:   ThemeDeepestFocus,
 	OnTabCurrentInk: ThemeOnDeepestFocus,
+	ModifiedInk:     ThemeWarning,
 	TabBorder:       NewEmptyBorder(Insets{Top: 2, Left: 4, Bottom: 2, Right: 4}),
 	Gap:             4,
 	LabelTheme:      defaultDockLabelTheme(),
@@ -63,6 +69,7 @@ type DockTabTheme struct {
 	OnTabFocusedInk Ink
 	TabCurrentInk   Ink
 	OnTabCurrentInk Ink
+	ModifiedInk     Ink
 	TabBorder       Border
 	LabelTheme      LabelTheme
 	ButtonTheme     ButtonTheme
@@ -73,9 +80,11 @@ type dockTab struct {
 	title    *Label
 	button   *Button
 	dockable Dockable
+	icon     Drawable
 	Panel
 	DockTabTheme
-	pressed bool
+	modified bool
+	pressed  bool
 }
 
 func newDockTab(dockable Dockable) *dockTab {
@@ -93,8 +102,10 @@ func newDockTab(dockable Dockable) *dockTab {
 	}
 	t.SetLayout(flex)
 	t.title.LabelTheme = t.LabelTheme
-	t.title.SetTitle(t.fullTitle())
-	t.title.Drawable = t.TitleIcon()
+	t.icon = t.TitleIcon()
+	t.modified = t.dockable.Modified()
+	t.title.SetTitle(t.dockable.Title())
+	t.title.Drawable = t.tabIcon()
 	t.title.SetLayoutData(&FlexLayoutData{HGrab: true, VAlign: align.Middle})
 	t.AddChild(t.title)
 	if _, ok := t.dockable.(TabCloser); ok {
@@ -123,21 +134,38 @@ func (t *dockTab) TitleIcon() Drawable {
 	return t.dockable.TitleIcon(Size{Width: fSize, Height: fSize})
 }
 
-func (t *dockTab) fullTitle() string {
-	var buffer strings.Builder
-	if t.dockable.Modified() {
-		buffer.WriteByte('*')
+// tabIcon returns the icon to show in the tab, decorated with a dot when the dockable has been modified.
+func (t *dockTab) tabIcon() Drawable {
+	if !t.modified {
+		return t.icon
+	}
+	fSize := t.title.Font.Baseline()
+	dot := &dotDrawable{Ink: t.ModifiedInk, Size: Size{Width: fSize / 2, Height: fSize / 2}}
+	if t.icon == nil {
+		return dot
+	}
+	return &CompositeDrawable{
+		Base: t.icon,
+		Overlays: []DrawableOverlay{
+			{
+				Drawable: dot,
+				Offset:   Point{X: fSize / 8, Y: -fSize / 8},
+				HAlign:   align.End,
+				VAlign:   align.Start,
+			},
+		},
 	}
-	buffer.WriteString(t.dockable.Title())
-	return buffer.String()
 }
 
 func (t *dockTab) updateTitle() {
-	drawable := t.TitleIcon()
-	title := t.fullTitle()
-	if title != t.title.String() || t.title.Drawable != drawable {
+	icon := t.TitleIcon()
+	modified := t.dockable.Modified()
+	title := t.dockable.Title()
+	if title != t.title.String() || t.icon != icon || t.modified != modified {
+		t.icon = icon
+		t.modified = modified
 		t.title.SetTitle(title)
-		t.title.Drawable = drawable
+		t.title.Drawable = t.tabIcon()
 		t.NeedsLayout = true
 		t.title.NeedsLayout = true
 		if p := t.Parent(); p != nil {
@@ -226,6 +254,16 @@ func (t *dockTab) mouseDown(where Point, button, clickCount int, _ Modifiers) bo
 			}
 		}
 	}
+	if button == ButtonLeft && clickCount == 2 {
+		if dc := Ancestor[*DockContainer](t.dockable); dc != nil && dc.Dock != nil {
+			if dc.Dock.MaximizedContainer == dc {
+				dc.Dock.Restore()
+			} else {
+				dc.Dock.Maximize(dc)
+			}
+			return true
+		}
+	}
 	t.pressed = true
 	t.MarkForRedraw()
 	return true
@@ -237,14 +275,21 @@ func (t *dockTab) mouseDrag(where Point, _ int, _ Modifiers) bool {
 	}
 	if t.IsDragGesture(where) {
 		if dc := Ancestor[*DockContainer](t.dockable); dc != nil {
-			icon := t.TitleIcon()
-			size := icon.LogicalSize()
-			t.StartDataDrag(&DragData{
-				Data:     map[string]any{dc.Dock.DragKey: t.dockable},
-				Drawable: icon,
-				Ink:      t.title.OnBackgroundInk,
-				Offset:   Point{X: -size.Width / 2, Y: -size.Height / 2},
-			})
+			dragData := &DragData{Data: map[string]any{dc.Dock.DragKey: t.dockable}}
+			scale, _ := t.Window().BackingScale()
+			if snapshot, err := t.SnapshotToImage(scale); err == nil {
+				// Draw a translucent copy of the tab that follows the cursor from where it was grabbed
+				dragData.Drawable = snapshot
+				dragData.Ink = Black.SetAlphaIntensity(0.6)
+				dragData.Offset = Point{X: -where.X, Y: -where.Y}
+			} else {
+				icon := t.TitleIcon()
+				size := icon.LogicalSize()
+				dragData.Drawable = icon
+				dragData.Ink = t.title.OnBackgroundInk
+				dragData.Offset = Point{X: -size.Width / 2, Y: -size.Height / 2}
+			}
+			t.StartDataDrag(dragData)
 		}
 	}
 	return true
@@ -268,3 +313,17 @@ func (t *dockTab) mouseUp(where Point, _ int, _ Modifiers) bool {
 	t.MarkForRedraw()
 	return true
 }
+
+// dotDrawable draws a filled circle, ignoring the ink of the paint it is given.
+type dotDrawable struct {
+	Ink  Ink
+	Size Size
+}
+
+func (d *dotDrawable) LogicalSize() Size {
+	return d.Size
+}
+
+func (d *dotDrawable) DrawInRect(canvas *Canvas, rect Rect, _ *SamplingOptions, _ *Paint) {
+	canvas.DrawOval(rect, d.Ink.Paint(canvas, rect, paintstyle.Fill))
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+// FieldLines exposes the lines a Field displays when laid out at the given width to the tests.
+func FieldLines(f *Field, width float32) []*Text {
+	f.linesBuiltFor = -1
+	f.prepareLines(width)
+	return f.lines
+}
@@ -17,11 +17,19 @@ import (
 
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/granularity"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 	"github.com/richardwilkes/unison/enums/pathop"
+	"github.com/richardwilkes/unison/enums/role"
+	"github.com/richardwilkes/unison/enums/rounding"
 )
 
+// DisableCaretBlink causes the caret in all fields to be shown continuously rather than blinking. Individual fields may
+// also disable blinking by setting their BlinkRate to 0.
+var DisableCaretBlink bool
+
 type lineEndingType byte
 
 const (
@@ -30,21 +38,29 @@ const (
 	softLineEnding
 )
 
+const fieldDragScrollInterval = time.Second / 30
+
 // DefaultFieldTheme holds the default FieldTheme values for Fields. Modifying this data will not alter existing Fields,
 // but will alter any Fields created in the future.
 var DefaultFieldTheme = FieldTheme{
-	Font:             FieldFont,
-	BackgroundInk:    ThemeSurface,
-	OnBackgroundInk:  ThemeOnSurface,
-	EditableInk:      ThemeDeepBelowSurface,
-	OnEditableInk:    ThemeOnDeepBelowSurface,
-	SelectionInk:     ThemeFocus,
-	OnSelectionInk:   ThemeOnFocus,
-	ErrorInk:         ThemeError,
-	OnErrorInk:       ThemeOnError,
-	BlinkRate:        560 * time.Millisecond,
-	MinimumTextWidth: 10,
-	HAlign:           align.Start,
+	Font:                   FieldFont,
+	BackgroundInk:          ThemeSurface,
+	OnBackgroundInk:        ThemeOnSurface,
+	EditableInk:            ThemeDeepBelowSurface,
+	OnEditableInk:          ThemeOnDeepBelowSurface,
+	SelectionInk:           ThemeFocus,
+	OnSelectionInk:         ThemeOnFocus,
+	InactiveSelectionInk:   ThemeSurfaceEdge,
+	OnInactiveSelectionInk: ThemeOnSurface,
+	ErrorInk:               ThemeError,
+	OnErrorInk:             ThemeOnError,
+	BracketMatchInk:        ThemeFocus,
+	BracketMismatchInk:     ThemeWarning,
+	BlinkRate:              560 * time.Millisecond,
+	MinimumTextWidth:       10,
+	CaretWidth:             1,
+	DragScrollRate:         1,
+	HAlign:                 align.Start,
 }
 
 // FieldTheme holds theming data for a Field.
@@ -57,39 +73,115 @@ type FieldTheme struct {
 	OnEditableInk          Ink
 	SelectionInk           Ink
 	OnSelectionInk         Ink
+	// InactiveSelectionInk and OnInactiveSelectionInk are used in place of SelectionInk and OnSelectionInk to draw the
+	// selection while the field does not have the focus. If InactiveSelectionInk is nil, the selection is not shown
+	// while the field does not have the focus.
+	InactiveSelectionInk   Ink
+	OnInactiveSelectionInk Ink
 	ErrorInk               Ink
 	OnErrorInk             Ink
-	BlinkRate              time.Duration
-	MinimumTextWidth       float32
-	HAlign                 align.Enum
+	CaretInk               Ink
+	// BracketMatchInk and BracketMismatchInk are used to outline the brackets highlighted when
+	// HighlightMatchingBrackets is set. BracketMismatchInk is used when the bracket next to the caret has no partner.
+	BracketMatchInk    Ink
+	BracketMismatchInk Ink
+	BlinkRate          time.Duration
+	MinimumTextWidth   float32
+	CaretWidth         float32
+	DragScrollRate     float32
+	HAlign             align.Enum
 }
 
 // Field provides a text input control.
 type Field struct {
 	ModifiedCallback func(before, after *FieldState)
 	ValidateCallback func() bool
-	runes            []rune
-	lines            []*Text
-	endsWithLineFeed []lineEndingType
-	Watermark        string
-	forceShowUntil   time.Time
+	// ValidateWithMessageCallback is used in preference to ValidateCallback, if set. When the content is invalid, the
+	// returned message will be made available via InvalidMessage() and shown as a tooltip.
+	ValidateWithMessageCallback func() (valid bool, message string)
+	// CompletionProvider, if set, is called after a rune is typed at the end of a single-line field's text. It should
+	// return possible completions for the text, in order of preference. The first completion that begins with the text,
+	// ignoring case, will be appended to the text, with the added portion selected so that further typing replaces it.
+	CompletionProvider func(text string) []string
+	// SelectionChangedCallback, if set, is called whenever the selection or caret position changes.
+	SelectionChangedCallback func(start, end, anchor int)
+	// ClickSelectionBehavior determines how much text a left mouse click selects, given the number of clicks made in
+	// quick succession. Defaults to DefaultClickSelectionBehavior. If nil, clicks only position the caret.
+	ClickSelectionBehavior func(clickCount int) granularity.Enum
+	Watermark              string
+	// WatermarkDecoration, if set, is used to draw the Watermark. A nil Font will use the field's font and a nil
+	// OnBackgroundInk will use a dimmed version of the field's normal text ink.
+	WatermarkDecoration *TextDecoration
+	runes               []rune
+	lines               []*Text
+	endsWithLineFeed    []lineEndingType
+	lineCache           map[string]*fieldLineCacheEntry
+	linesFont           Font
+	breaker             WordBreaker
+	invalidMessage      string
+	validationTooltip   *Panel
+	clickableRanges     []fieldClickableRange
+	lineBackgrounds     map[int]Ink
+	forceShowUntil      time.Time
 	FieldTheme
 	Panel
-	undoID             int64
-	selectionStart     int
-	selectionEnd       int
-	selectionAnchor    int
-	scrollOffset       Point
-	linesBuiltFor      float32
-	ObscurementRune    rune
-	AutoScroll         bool
-	NoSelectAllOnFocus bool
-	multiLine          bool
-	wrap               bool
-	showCursor         bool
-	pending            bool
-	extendByWord       bool
-	invalid            bool
+	// TabWidth is the number of spaces used for each level of indentation when TabInsertsSpaces is set, and the number
+	// of leading spaces removed by each level of outdentation. Values less than 1 are treated as 4.
+	TabWidth        int
+	undoID          int64
+	dragScrollGen   int
+	lineCacheGen    int
+	selectionStart  int
+	selectionEnd    int
+	selectionAnchor int
+	// AutoShrinkMinimumSize is the smallest font size AutoShrinkToFit will reduce the text to. Values less than 1 are
+	// treated as 1.
+	AutoShrinkMinimumSize float32
+	ObscurementRune       rune
+	dragScrollWhere       Point
+	scrollOffset          Point
+	linesBuiltFor         float32
+	AutoScroll            bool
+	NoSelectAllOnFocus    bool
+	// HideWatermarkWhenFocused causes the Watermark to be hidden while the field has the focus, rather than remaining
+	// visible until text is entered.
+	HideWatermarkWhenFocused bool
+	// AutoShrinkToFit causes the font size of a single-line, non-wrapping field to be reduced, down to
+	// AutoShrinkMinimumSize, until its text fits within the content width, rather than requiring it to be scrolled.
+	AutoShrinkToFit bool
+	// TabInsertsSpaces causes indentation to be inserted as TabWidth spaces rather than as a tab.
+	TabInsertsSpaces bool
+	// TabKeyIndents causes the Tab key to indent, and Shift+Tab to outdent, the lines of a multi-line field touched by
+	// the selection, rather than moving the focus. Tab inserts a single level of indentation at the caret when the
+	// selection doesn't span multiple lines.
+	TabKeyIndents bool
+	// HighlightMatchingBrackets causes the bracket next to the caret, along with its matching partner, to be outlined.
+	HighlightMatchingBrackets bool
+	multiLine                 bool
+	wrap                      bool
+	showCursor                bool
+	pending                   bool
+	extendByWord              bool
+	focusFromPointer          bool
+	dragScrolling             bool
+	invalid                   bool
+}
+
+// fieldLineCacheEntry holds the shaped text for a single logical line of a multi-line field, along with the lines it
+// was last wrapped into and the width used to do so.
+type fieldLineCacheEntry struct {
+	font       Font
+	text       *Text
+	parts      []*Text
+	generation int
+	wrapWidth  float32
+}
+
+type fieldClickableRange struct {
+	onClick func()
+	cursor  *Cursor
+	start   int
+	end     int
 }
 
 // FieldState holds the text and selection data for the field.
@@ -116,9 +208,13 @@ func NewField() *Field {
 	f.LostFocusCallback = f.DefaultFocusLost
 	f.MouseDownCallback = f.DefaultMouseDown
 	f.MouseDragCallback = f.DefaultMouseDrag
+	f.MouseUpCallback = f.DefaultMouseUp
+	f.MouseWheelCallback = f.DefaultMouseWheel
+	f.ClickSelectionBehavior = DefaultClickSelectionBehavior
 	f.UpdateCursorCallback = f.DefaultUpdateCursor
 	f.KeyDownCallback = f.DefaultKeyDown
 	f.RuneTypedCallback = f.DefaultRuneTyped
+	f.AccessibilityCallback = f.DefaultAccessibility
 	f.InstallCmdHandlers(CutItemID, func(_ any) bool { return f.CanCut() }, func(_ any) { f.Cut() })
 	f.InstallCmdHandlers(CopyItemID, func(_ any) bool { return f.CanCopy() }, func(_ any) { f.Copy() })
 	f.InstallCmdHandlers(PasteItemID, func(_ any) bool { return f.CanPaste() }, func(_ any) { f.Paste() })
@@ -136,6 +232,21 @@ func NewMultiLineField() *Field {
 	return f
 }
 
+// DefaultAccessibility provides the default accessibility information. The text of obscured fields is not revealed.
+func (f *Field) DefaultAccessibility() AccessibilityInfo {
+	info := AccessibilityInfo{
+		Name:           f.Watermark,
+		Value:          string(f.obscureIfNeeded(f.runes)),
+		SelectionStart: f.selectionStart,
+		SelectionEnd:   f.selectionEnd,
+		Role:           role.TextField,
+	}
+	if f.invalid {
+		info.State |= AccessibilityInvalid
+	}
+	return info
+}
+
 // CurrentUndoID returns the undo ID to use.
 func (f *Field) CurrentUndoID() int64 {
 	return f.undoID
@@ -151,11 +262,64 @@ func (f *Field) Wrap() bool {
 	return f.wrap
 }
 
-// SetWrap sets the wrapping attribute.
+// SetWrap sets the wrapping attribute. The text is reflowed and the scroll offset is re-derived from the selection so
+// that the caret remains visible.
 func (f *Field) SetWrap(wrap bool) {
 	if wrap != f.wrap {
 		f.wrap = wrap
+		f.linesBuiltFor = -1
+		f.scrollOffset = Point{}
 		f.MarkForLayoutAndRedraw()
+		f.ScrollSelectionIntoView()
+	}
+}
+
+// WordBreaker returns the WordBreaker used to determine word boundaries for selection and where lines may be wrapped.
+func (f *Field) WordBreaker() WordBreaker {
+	if f.breaker != nil {
+		return f.breaker
+	}
+	return DefaultWordBreaker
+}
+
+// SetWordBreaker sets the WordBreaker to use in place of DefaultWordBreaker. Pass nil to return to using
+// DefaultWordBreaker. The text is reflowed.
+func (f *Field) SetWordBreaker(breaker WordBreaker) {
+	f.breaker = breaker
+	f.lineCache = nil
+	f.linesBuiltFor = -1
+	f.MarkForLayoutAndRedraw()
+}
+
+// LineBackground returns the ink used to fill the background of the specified logical line, i.e. a line delimited by
+// line feeds rather than by wrapping, or nil if none has been set.
+func (f *Field) LineBackground(lineIndex int) Ink {
+	return f.lineBackgrounds[lineIndex]
+}
+
+// SetLineBackground sets the ink used to fill the background of the specified logical line, i.e. a line delimited by
+// line feeds rather than by wrapping, behind its text and any selection. Lines wrapped from it share its background.
+// Pass nil to remove it.
+func (f *Field) SetLineBackground(lineIndex int, ink Ink) {
+	if ink == nil {
+		if _, exists := f.lineBackgrounds[lineIndex]; !exists {
+			return
+		}
+		delete(f.lineBackgrounds, lineIndex)
+	} else {
+		if f.lineBackgrounds == nil {
+			f.lineBackgrounds = make(map[int]Ink)
+		}
+		f.lineBackgrounds[lineIndex] = ink
+	}
+	f.MarkForRedraw()
+}
+
+// ClearLineBackgrounds removes all line backgrounds set via SetLineBackground.
+func (f *Field) ClearLineBackgrounds() {
+	if len(f.lineBackgrounds) != 0 {
+		f.lineBackgrounds = nil
+		f.MarkForRedraw()
 	}
 }
 
@@ -176,7 +340,8 @@ func (f *Field) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 	if b := f.Border(); b != nil {
 		insets = b.Insets()
 	}
-	lines, _ := f.buildLines(hint.Width - (2 + insets.Width()))
+	caretWidth := f.caretWidth()
+	lines, _, _ := f.buildLines(hint.Width - (2*caretWidth + insets.Width()))
 	for _, line := range lines {
 		size := line.Extents()
 		if prefSize.Width < size.Width {
@@ -190,8 +355,8 @@ func (f *Field) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 	if height := f.Font.LineHeight(); prefSize.Height < height {
 		prefSize.Height = height
 	}
-	prefSize.Width += 2 // Allow room for the cursor on either side of the text
-	minWidth := f.MinimumTextWidth + 2 + insets.Width()
+	prefSize.Width += 2 * caretWidth // Allow room for the cursor on either side of the text
+	minWidth := f.MinimumTextWidth + 2*caretWidth + insets.Width()
 	prefSize = prefSize.Add(insets.Size()).Ceil()
 	if hint.Width >= 1 && hint.Width < minWidth {
 		hint.Width = minWidth
@@ -207,46 +372,86 @@ func (f *Field) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 
 func (f *Field) prepareLines(width float32) {
 	width = max(width, 0)
-	f.lines, f.endsWithLineFeed = f.buildLines(width)
+	f.lines, f.endsWithLineFeed, f.linesFont = f.buildLines(width)
 	f.linesBuiltFor = width
 }
 
 func (f *Field) prepareLinesForCurrentWidth() {
-	f.prepareLines(f.ContentRect(false).Width - 2)
+	f.prepareLines(f.ContentRect(false).Width - 2*f.caretWidth())
 }
 
-func (f *Field) buildLines(wrapWidth float32) (lines []*Text, endsWithLineFeed []lineEndingType) {
+// textFont returns the font the current lines were built with, which may be smaller than the field's Font when
+// AutoShrinkToFit is enabled.
+func (f *Field) textFont() Font {
+	if f.linesFont != nil {
+		return f.linesFont
+	}
+	return f.Font
+}
+
+// fontForWidth returns the font to use for the text when it must fit within the given width.
+func (f *Field) fontForWidth(width float32) Font {
+	if !f.AutoShrinkToFit || f.multiLine || f.wrap || width <= 0 || len(f.runes) == 0 {
+		return f.Font
+	}
+	runes := f.obscureIfNeeded(f.runes)
+	textWidth := NewTextFromRunes(runes, &TextDecoration{Font: f.Font}).Width()
+	if textWidth <= width {
+		return f.Font
+	}
+	desc := f.Font.Descriptor()
+	minSize := min(max(f.AutoShrinkMinimumSize, 1), desc.Size)
+	desc.Size = max(xmath.Floor(desc.Size*width/textWidth), minSize)
+	font := desc.Font()
+	for desc.Size > minSize && NewTextFromRunes(runes, &TextDecoration{Font: font}).Width() > width {
+		desc.Size = max(desc.Size-0.5, minSize)
+		font = desc.Font()
+	}
+	return font
+}
+
+func (f *Field) buildLines(wrapWidth float32) (lines []*Text, endsWithLineFeed []lineEndingType, font Font) {
 	if wrapWidth == f.linesBuiltFor && f.linesBuiltFor >= 0 {
-		return f.lines, f.endsWithLineFeed
+		return f.lines, f.endsWithLineFeed, f.linesFont
 	}
+	font = f.fontForWidth(wrapWidth)
 	if len(f.runes) != 0 {
 		lines = make([]*Text, 0)
-		decoration := &TextDecoration{Font: f.Font}
+		decoration := &TextDecoration{Font: font}
 		if f.multiLine {
 			endsWithLineFeed = make([]lineEndingType, 0, 16)
-			for _, line := range strings.Split(string(f.runes), "\n") {
-				one := NewText(f.obscureStringIfNeeded(line), decoration)
-				if f.wrap && wrapWidth > 0 {
-					parts := one.BreakToWidth(wrapWidth)
-					for i, part := range parts {
-						lines = append(lines, part)
-						var eol lineEndingType
-						if i == len(parts)-1 {
-							eol = hardLineEnding
-						} else {
-							eol = softLineEnding
-						}
-						endsWithLineFeed = append(endsWithLineFeed, eol)
+			if f.lineCache == nil {
+				f.lineCache = make(map[string]*fieldLineCacheEntry)
+			}
+			f.lineCacheGen++
+			text := string(f.runes)
+			for {
+				line, remaining, more := strings.Cut(text, "\n")
+				parts := f.logicalLineParts(f.obscureStringIfNeeded(line), decoration.Font, wrapWidth)
+				for i, part := range parts {
+					lines = append(lines, part)
+					var eol lineEndingType
+					if i == len(parts)-1 {
+						eol = hardLineEnding
+					} else {
+						eol = softLineEnding
 					}
-				} else {
-					lines = append(lines, one)
-					endsWithLineFeed = append(endsWithLineFeed, hardLineEnding)
+					endsWithLineFeed = append(endsWithLineFeed, eol)
+				}
+				if !more {
+					break
+				}
+				text = remaining
+			}
+			for line, entry := range f.lineCache {
+				if entry.generation != f.lineCacheGen {
+					delete(f.lineCache, line)
 				}
 			}
 		} else {
 			one := NewTextFromRunes(f.obscureIfNeeded(f.runes), decoration)
 			if f.wrap && wrapWidth > 0 {
-				lines = append(lines, one.BreakToWidth(wrapWidth)...)
+				lines = append(lines, one.BreakToWidthUsing(wrapWidth, f.WordBreaker())...)
 			} else {
 				lines = append(lines, one)
 			}
@@ -256,29 +461,83 @@ func (f *Field) buildLines(wrapWidth float32) (lines []*Text, endsWithLineFeed [
 	return
 }
 
+// logicalLineParts returns the lines a single logical line should be displayed as. Shaped text is reused from the
+// previous build for lines whose content is unchanged, so that an edit only requires the affected lines to be shaped
+// again. The entries used are marked with the current generation, so that unused ones can be discarded afterward.
+func (f *Field) logicalLineParts(line string, font Font, width float32) []*Text {
+	entry, ok := f.lineCache[line]
+	if !ok || entry.font != font {
+		entry = &fieldLineCacheEntry{
+			font: font,
+			text: NewText(line, &TextDecoration{Font: font}),
+		}
+		// The line may be a substring of the entire text, so clone it to avoid retaining the rest as part of the key
+		f.lineCache[strings.Clone(line)] = entry
+	}
+	entry.generation = f.lineCacheGen
+	if !f.wrap || width <= 0 {
+		return []*Text{entry.text}
+	}
+	if entry.parts == nil || entry.wrapWidth != width {
+		entry.parts = entry.text.BreakToWidthUsing(width, f.WordBreaker())
+		entry.wrapWidth = width
+	}
+	return entry.parts
+}
+
 func (f *Field) obscureStringIfNeeded(in string) string {
 	if f.ObscurementRune == 0 {
 		return in
 	}
-	r := []rune(in)
-	replacement := make([]rune, len(r))
-	for i := range r {
-		replacement[i] = f.ObscurementRune
-	}
-	return string(replacement)
+	return string(f.obscureIfNeeded([]rune(in)))
 }
 
+// obscureIfNeeded replaces the runes with the ObscurementRune, if one has been set. Only one ObscurementRune is emitted
+// per grapheme cluster, so that multi-rune characters such as emoji don't reveal their structure. The remaining runes
+// of each cluster are replaced with a zero-width space, keeping rune indexes aligned with the original content.
 func (f *Field) obscureIfNeeded(in []rune) []rune {
 	if f.ObscurementRune == 0 {
 		return in
 	}
 	replacement := make([]rune, len(in))
-	for i := range in {
-		replacement[i] = f.ObscurementRune
+	regionalIndicators := 0
+	for i, r := range in {
+		if i != 0 && continuesGraphemeCluster(in[i-1], r, regionalIndicators) {
+			replacement[i] = '\u200B'
+		} else {
+			replacement[i] = f.ObscurementRune
+		}
+		if r >= 0x1F1E6 && r <= 0x1F1FF {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
 	}
 	return replacement
 }
 
+// continuesGraphemeCluster returns true if r extends the grapheme cluster that prev is part of. precedingRIs is the
+// number of consecutive regional indicators ending with prev. This is an approximation of the Unicode rules that covers
+// combining marks, emoji modifier and ZWJ sequences, tag sequences, and flags.
+func continuesGraphemeCluster(prev, r rune, precedingRIs int) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return true
+	case r == '\u200D' || prev == '\u200D': // Zero width joiner
+		return true
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc): // Includes variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tags
+		return true
+	case r >= 0x1F1E6 && r <= 0x1F1FF: // Regional indicators pair up to form flags
+		return precedingRIs%2 == 1
+	default:
+		return false
+	}
+}
+
 // DefaultDraw provides the default drawing.
 func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 	var bg, fg Ink
@@ -295,10 +554,11 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 		fg = f.OnBackgroundInk
 	}
 	rect := f.ContentRect(true)
-	canvas.DrawRect(rect, bg.Paint(canvas, rect, paintstyle.Fill))
+	canvas.FillRectWithInk(rect, bg)
 	rect = f.ContentRect(false)
 	canvas.ClipRect(rect, pathop.Intersect, false)
-	f.prepareLines(rect.Width - 2)
+	f.prepareLines(rect.Width - 2*f.caretWidth())
+	font := f.textFont()
 	ink := fg
 	if !enabled {
 		ink = &ColorFilteredInk{
@@ -307,45 +567,61 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 		}
 	}
 	textTop := rect.Y + f.scrollOffset.Y
+	caretWidth := f.caretWidth()
+	caretInk := f.CaretInk
+	if caretInk == nil {
+		caretInk = fg
+	}
 	focused := f.Focused()
 	hasSelectionRange := f.HasSelectionRange()
+	selectionInk := f.SelectionInk
+	onSelectionInk := f.OnSelectionInk
+	if !focused {
+		selectionInk = f.InactiveSelectionInk
+		onSelectionInk = f.OnInactiveSelectionInk
+	}
 	start := 0
 	if len(f.runes) == 0 {
-		if f.Watermark != "" {
-			text := NewText(f.Watermark, &TextDecoration{
-				Font: f.Font,
-				OnBackgroundInk: &ColorFilteredInk{
-					OriginalInk: ink,
-					ColorFilter: Alpha30Filter(),
-				},
-			})
+		if f.Watermark != "" && !(focused && f.HideWatermarkWhenFocused) {
+			text := NewText(f.Watermark, f.watermarkDecoration(ink))
 			text.Draw(canvas, f.textLeft(text, rect), textTop+text.Baseline())
 		}
 		if !hasSelectionRange && enabled && focused {
 			if f.showCursor {
-				rect.X = f.textLeftForWidth(0, rect) + f.scrollOffset.X - 0.5
-				rect.Width = 1
-				rect.Height = f.Font.LineHeight()
-				canvas.DrawRect(rect, fg.Paint(canvas, rect, paintstyle.Fill))
+				rect.X = f.textLeftForWidth(0, rect) + f.scrollOffset.X - caretWidth/2
+				rect.Width = caretWidth
+				rect.Height = font.LineHeight()
+				canvas.FillRectWithInk(rect, caretInk)
 			}
 			f.scheduleBlink()
 		}
 	} else {
+		logicalLine := 0
 		for i, line := range f.lines {
 			textLeft := f.textLeft(line, rect)
 			textBaseLine := textTop + line.Baseline()
-			textHeight := max(line.Height(), f.Font.LineHeight())
+			textHeight := max(line.Height(), font.LineHeight())
 			end := start + len(line.Runes())
 			if f.endsWithLineFeed[i] == hardLineEnding {
 				end++
 			}
-			if enabled && focused && hasSelectionRange && f.selectionStart < end && f.selectionEnd > start {
+			if lineInk, ok := f.lineBackgrounds[logicalLine]; ok {
+				lineRect := Rect{
+					Point: Point{X: rect.X, Y: textTop},
+					Size:  Size{Width: rect.Width, Height: textHeight},
+				}
+				canvas.FillRectWithInk(lineRect, lineInk)
+			}
+			if f.endsWithLineFeed[i] == hardLineEnding {
+				logicalLine++
+			}
+			if enabled && selectionInk != nil && hasSelectionRange && f.selectionStart < end && f.selectionEnd > start {
 				left := textLeft + f.scrollOffset.X
 				selStart := max(f.selectionStart, start)
 				selEnd := min(f.selectionEnd, end)
 				if selStart > start {
 					t := NewTextFromRunes(f.obscureIfNeeded(f.runes[start:selStart]), &TextDecoration{
-						Font:            f.Font,
+						Font:            font,
 						OnBackgroundInk: ink,
 					})
 					t.Draw(canvas, left, textBaseLine)
@@ -356,15 +632,15 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 					e--
 				}
 				t := NewTextFromRunes(f.obscureIfNeeded(f.runes[selStart:e]), &TextDecoration{
-					Font:            f.Font,
-					OnBackgroundInk: f.OnSelectionInk,
+					Font:            font,
+					OnBackgroundInk: onSelectionInk,
 				})
 				right := left + t.Width()
 				selRect := Rect{
 					Point: Point{X: left, Y: textTop},
 					Size:  Size{Width: right - left, Height: textHeight},
 				}
-				canvas.DrawRect(selRect, f.SelectionInk.Paint(canvas, selRect, paintstyle.Fill))
+				canvas.FillRectWithInk(selRect, selectionInk)
 				t.Draw(canvas, left, textBaseLine)
 				if selEnd < end {
 					e = end
@@ -372,7 +648,7 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 						e--
 					}
 					NewTextFromRunes(f.obscureIfNeeded(f.runes[selEnd:e]), &TextDecoration{
-						Font:            f.Font,
+						Font:            font,
 						OnBackgroundInk: ink,
 					}).Draw(canvas, right, textBaseLine)
 				}
@@ -382,11 +658,11 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 			}
 			if !hasSelectionRange && enabled && focused && f.selectionEnd >= start && (f.selectionEnd < end || (!f.multiLine && f.selectionEnd <= end)) {
 				if f.showCursor {
-					t := NewTextFromRunes(f.obscureIfNeeded(f.runes[start:f.selectionEnd]), &TextDecoration{Font: f.Font})
-					canvas.DrawRect(Rect{
-						Point: Point{X: textLeft + t.Width() + f.scrollOffset.X - 0.5, Y: textTop},
-						Size:  Size{Width: 1, Height: textHeight},
-					}, fg.Paint(canvas, rect, paintstyle.Fill))
+					t := NewTextFromRunes(f.obscureIfNeeded(f.runes[start:f.selectionEnd]), &TextDecoration{Font: font})
+					canvas.FillRectWithInk(Rect{
+						Point: Point{X: textLeft + t.Width() + f.scrollOffset.X - caretWidth/2, Y: textTop},
+						Size:  Size{Width: caretWidth, Height: textHeight},
+					}, caretInk)
 				}
 				f.scheduleBlink()
 			}
@@ -394,6 +670,110 @@ func (f *Field) DefaultDraw(canvas *Canvas, _ Rect) {
 			start = end
 		}
 	}
+	if f.HighlightMatchingBrackets && enabled && focused && !hasSelectionRange {
+		f.drawMatchingBrackets(canvas)
+	}
+}
+
+func (f *Field) drawMatchingBrackets(canvas *Canvas) {
+	index := -1
+	for _, i := range []int{f.selectionEnd - 1, f.selectionEnd} {
+		if i >= 0 && i < len(f.runes) && isBracket(f.runes[i]) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return
+	}
+	if partner, ok := f.MatchingBracket(index); ok {
+		f.drawBracketOutline(canvas, index, f.BracketMatchInk)
+		f.drawBracketOutline(canvas, partner, f.BracketMatchInk)
+	} else {
+		f.drawBracketOutline(canvas, index, f.BracketMismatchInk)
+	}
+}
+
+func (f *Field) drawBracketOutline(canvas *Canvas, index int, ink Ink) {
+	if ink == nil {
+		return
+	}
+	pt := f.FromSelectionIndex(index)
+	r := Rect{Point: pt, Size: Size{Height: f.lineHeightAt(pt.Y)}}
+	if next := f.FromSelectionIndex(index + 1); next.Y == pt.Y {
+		r.Width = next.X - pt.X
+	} else {
+		r.Width = f.textFont().SimpleWidth(string(f.runes[index]))
+	}
+	r = r.Inset(NewUniformInsets(0.5))
+	WithInkPaint(canvas, r, ink, paintstyle.Stroke, func(paint *Paint) {
+		paint.SetStrokeWidth(1)
+		canvas.DrawRect(r, paint)
+	})
+}
+
+var bracketPairs = map[rune]rune{'(': ')', '[': ']', '{': '}', ')': '(', ']': '[', '}': '{'}
+
+func isBracket(r rune) bool {
+	_, ok := bracketPairs[r]
+	return ok
+}
+
+func isOpeningBracket(r rune) bool {
+	return r == '(' || r == '[' || r == '{'
+}
+
+// MatchingBracket returns the index of the bracket that pairs with the one at the index, taking nesting into account.
+// The brackets recognized are (), [] and {}. Returns false if there is no bracket at the index, or if it has no
+// matching partner because the brackets are unbalanced or improperly nested.
+func (f *Field) MatchingBracket(index int) (int, bool) {
+	if index < 0 || index >= len(f.runes) || !isBracket(f.runes[index]) {
+		return -1, false
+	}
+	opening := isOpeningBracket(f.runes[index])
+	step := 1
+	if !opening {
+		step = -1
+	}
+	expected := []rune{bracketPairs[f.runes[index]]}
+	for i := index + step; i >= 0 && i < len(f.runes); i += step {
+		r := f.runes[i]
+		if !isBracket(r) {
+			continue
+		}
+		if isOpeningBracket(r) == opening {
+			expected = append(expected, bracketPairs[r])
+			continue
+		}
+		last := len(expected) - 1
+		if r != expected[last] {
+			return -1, false
+		}
+		if last == 0 {
+			return i, true
+		}
+		expected = expected[:last]
+	}
+	return -1, false
+}
+
+func (f *Field) watermarkDecoration(ink Ink) *TextDecoration {
+	var decoration *TextDecoration
+	if f.WatermarkDecoration != nil {
+		decoration = f.WatermarkDecoration.Clone()
+	} else {
+		decoration = &TextDecoration{}
+	}
+	if decoration.Font == nil {
+		decoration.Font = f.Font
+	}
+	if decoration.OnBackgroundInk == nil {
+		decoration.OnBackgroundInk = &ColorFilteredInk{
+			OriginalInk: ink,
+			ColorFilter: Alpha30Filter(),
+		}
+	}
+	return decoration
 }
 
 // Invalid returns true if the field is currently marked as invalid.
@@ -402,6 +782,10 @@ func (f *Field) Invalid() bool {
 }
 
 func (f *Field) scheduleBlink() {
+	if f.blinkDisabled() {
+		f.showCursor = true
+		return
+	}
 	window := f.Window()
 	if window != nil && window.IsValid() && !f.pending && f.Enabled() && f.Focused() {
 		f.pending = true
@@ -413,6 +797,13 @@ func (f *Field) blink() {
 	window := f.Window()
 	if window != nil && window.IsValid() {
 		f.pending = false
+		if f.blinkDisabled() {
+			if !f.showCursor {
+				f.showCursor = true
+				f.MarkForRedraw()
+			}
+			return
+		}
 		if time.Now().After(f.forceShowUntil) {
 			f.showCursor = !f.showCursor
 			f.MarkForRedraw()
@@ -421,9 +812,15 @@ func (f *Field) blink() {
 	}
 }
 
-// DefaultFocusGained provides the default focus gained handling.
+func (f *Field) blinkDisabled() bool {
+	return DisableCaretBlink || ReducedMotion || f.BlinkRate <= 0
+}
+
+// DefaultFocusGained provides the default focus gained handling. When the focus arrives via the keyboard, the text is
+// selected unless NoSelectAllOnFocus is set. When it arrives via a mouse press, the selection is left for the mouse
+// handling to establish, which consults InitialClickSelectsAll.
 func (f *Field) DefaultFocusGained() {
-	if !f.NoSelectAllOnFocus && !f.HasSelectionRange() {
+	if !f.focusFromPointer && !f.NoSelectAllOnFocus && !f.HasSelectionRange() {
 		f.SelectAll()
 	}
 	f.showCursor = true
@@ -439,17 +836,33 @@ func (f *Field) DefaultFocusLost() {
 
 // DefaultMouseDown provides the default mouse down handling.
 func (f *Field) DefaultMouseDown(where Point, button, clickCount int, mod Modifiers) bool {
+	if button == ButtonLeft && clickCount == 1 && mod&NonStickyModifiers == 0 {
+		if r := f.clickableRangeAt(where); r != nil {
+			if r.onClick != nil {
+				toolbox.Call(r.onClick)
+			}
+			return true
+		}
+	}
 	f.undoID = NextUndoID()
 	wasFocused := f.Focused()
+	f.focusFromPointer = true
 	f.RequestFocus()
+	f.focusFromPointer = false
 	if button == ButtonLeft {
 		f.extendByWord = false
-		switch clickCount {
-		case 2:
-			start, end := f.findWordAt(f.ToSelectionIndex(where))
+		behavior := granularity.Position
+		if f.ClickSelectionBehavior != nil {
+			behavior = f.ClickSelectionBehavior(clickCount)
+		}
+		switch behavior {
+		case granularity.Word:
+			start, end := f.findWordAt(f.toSelectionIndex(where, rounding.Floor))
 			f.SetSelection(start, end)
 			f.extendByWord = true
-		case 3:
+		case granularity.Paragraph:
+			f.SelectParagraphAt(f.toSelectionIndex(where, rounding.Floor))
+		case granularity.Everything:
 			f.SelectAll()
 		default:
 			selectAll := false
@@ -486,6 +899,68 @@ func (f *Field) DefaultMouseDown(where Point, button, clickCount int, mod Modifi
 
 // DefaultMouseDrag provides the default mouse drag handling.
 func (f *Field) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
+	f.extendSelectionTo(where)
+	f.dragScrollWhere = where
+	if !f.dragScrolling && f.AutoScroll && f.DragScrollRate > 0 && !where.In(f.ContentRect(false)) {
+		f.dragScrolling = true
+		f.dragScrollGen++
+		f.scheduleDragScroll()
+	}
+	return true
+}
+
+// DefaultMouseUp provides the default mouse up handling.
+func (f *Field) DefaultMouseUp(_ Point, _ int, _ Modifiers) bool {
+	if f.dragScrolling {
+		f.dragScrolling = false
+		f.dragScrollGen++
+	}
+	return true
+}
+
+func (f *Field) scheduleDragScroll() {
+	gen := f.dragScrollGen
+	InvokeTaskAfter(func() { f.dragScroll(gen) }, fieldDragScrollInterval)
+}
+
+// dragScroll scrolls the field while the mouse is held outside of its content area during a selection drag, at a rate
+// proportional to how far outside it the mouse is, extending the selection as it goes.
+func (f *Field) dragScroll(gen int) {
+	if !f.dragScrolling || gen != f.dragScrollGen {
+		return
+	}
+	if w := f.Window(); w == nil || !w.IsValid() || !f.Focused() {
+		f.dragScrolling = false
+		return
+	}
+	rect := f.ContentRect(false)
+	var overshoot Point
+	switch {
+	case f.dragScrollWhere.X < rect.X:
+		overshoot.X = f.dragScrollWhere.X - rect.X
+	case f.dragScrollWhere.X >= rect.Right():
+		overshoot.X = f.dragScrollWhere.X - rect.Right()
+	}
+	if f.multiLine {
+		switch {
+		case f.dragScrollWhere.Y < rect.Y:
+			overshoot.Y = f.dragScrollWhere.Y - rect.Y
+		case f.dragScrollWhere.Y >= rect.Bottom():
+			overshoot.Y = f.dragScrollWhere.Y - rect.Bottom()
+		}
+	}
+	if overshoot.X == 0 && overshoot.Y == 0 {
+		f.dragScrolling = false
+		return
+	}
+	// Extending the selection to a point beyond the edge causes the field to scroll to keep the caret visible, so
+	// scaling the overshoot controls how far each tick scrolls.
+	f.extendSelectionTo(f.dragScrollWhere.Sub(overshoot).Add(overshoot.Mul(f.DragScrollRate)))
+	f.scheduleDragScroll()
+}
+
+// extendSelectionTo extends the selection from the anchor to the position under the point, honoring word extension.
+func (f *Field) extendSelectionTo(where Point) {
 	oldAnchor := f.selectionAnchor
 	pos := f.ToSelectionIndex(where)
 	var start, end int
@@ -525,11 +1000,13 @@ func (f *Field) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
 		}
 	}
 	f.setSelection(start, end, oldAnchor)
-	return true
 }
 
 // DefaultUpdateCursor provides the default cursor update handling.
-func (f *Field) DefaultUpdateCursor(_ Point) *Cursor {
+func (f *Field) DefaultUpdateCursor(where Point) *Cursor {
+	if r := f.clickableRangeAt(where); r != nil && r.cursor != nil {
+		return r.cursor
+	}
 	if f.Enabled() {
 		return TextCursor()
 	}
@@ -598,7 +1075,17 @@ func (f *Field) DefaultKeyDown(keyCode KeyCode, mod Modifiers, _ bool) bool {
 			f.handleHome(false, mod.ShiftDown())
 		}
 	case KeyTab:
-		return false
+		if !f.TabKeyIndents || !f.multiLine {
+			return false
+		}
+		switch first, last := f.selectedLines(); {
+		case mod.ShiftDown():
+			f.OutdentSelection()
+		case first != last:
+			f.IndentSelection()
+		default:
+			f.insertIndent()
+		}
 	case KeyReturn, KeyNumPadEnter:
 		f.undoID = NextUndoID()
 		if f.multiLine {
@@ -628,9 +1115,30 @@ func (f *Field) DefaultRuneTyped(ch rune) bool {
 	f.linesBuiltFor = -1
 	f.SetSelectionTo(f.selectionStart + 1)
 	f.notifyOfModification(before, f.GetFieldState())
+	f.applyCompletion()
 	return true
 }
 
+func (f *Field) applyCompletion() {
+	if f.CompletionProvider == nil || f.multiLine || f.HasSelectionRange() || f.selectionStart != len(f.runes) ||
+		len(f.runes) == 0 {
+		return
+	}
+	text := string(f.runes)
+	typed := len(f.runes)
+	for _, one := range f.CompletionProvider(text) {
+		completion := []rune(one)
+		if len(completion) > typed && strings.EqualFold(string(completion[:typed]), text) {
+			before := f.GetFieldState()
+			f.runes = append(f.runes, completion[typed:]...)
+			f.linesBuiltFor = -1
+			f.setSelection(typed, len(f.runes), typed)
+			f.notifyOfModification(before, f.GetFieldState())
+			return
+		}
+	}
+}
+
 func (f *Field) handleHome(lineOnly, extend bool) {
 	f.undoID = NextUndoID()
 	switch {
@@ -866,10 +1374,10 @@ func (f *Field) handleArrowDown(extend, byWord bool) {
 
 func (f *Field) lineHeightAt(y float32) float32 {
 	if len(f.lines) == 0 {
-		return f.Font.LineHeight()
+		return f.textFont().LineHeight()
 	}
 	index, _ := f.lineIndexForY(y)
-	return max(f.lines[index].Height(), f.Font.LineHeight())
+	return max(f.lines[index].Height(), f.textFont().LineHeight())
 }
 
 // CanCut returns true if the field has a selection that can be cut.
@@ -897,9 +1405,30 @@ func (f *Field) Copy() {
 	}
 }
 
-// CanPaste returns true if the clipboard has content that can be pasted into the field.
+// CanPaste returns true if the clipboard has content that can be pasted into the field and the result would pass
+// validation.
 func (f *Field) CanPaste() bool {
-	return GlobalClipboard.GetText() != ""
+	return GlobalClipboard.GetText() != "" && f.WouldPasteBeValid()
+}
+
+// WouldPasteBeValid returns true if pasting the current clipboard content into the field would result in content that
+// passes validation. The field's content and validation state are left unchanged.
+func (f *Field) WouldPasteBeValid() bool {
+	if f.ValidateWithMessageCallback == nil && f.ValidateCallback == nil {
+		return true
+	}
+	saved := f.runes
+	savedTooltip := f.Tooltip
+	f.runes = f.RunesIfPasted([]rune(GlobalClipboard.GetText()))
+	defer func() {
+		f.runes = saved
+		f.Tooltip = savedTooltip
+	}()
+	if f.ValidateWithMessageCallback != nil {
+		valid, _ := f.ValidateWithMessageCallback()
+		return valid
+	}
+	return f.ValidateCallback()
 }
 
 // Paste any text on the clipboard into the field.
@@ -982,6 +1511,7 @@ func (f *Field) SetText(text string) {
 }
 
 func (f *Field) notifyOfModification(before, after *FieldState) {
+	f.clickableRanges = nil
 	f.MarkForRedraw()
 	if f.ModifiedCallback != nil {
 		f.ModifiedCallback(before, after)
@@ -992,15 +1522,48 @@ func (f *Field) notifyOfModification(before, after *FieldState) {
 // Validate forces field content validation to be run.
 func (f *Field) Validate() {
 	invalid := false
-	if f.ValidateCallback != nil {
+	var msg string
+	switch {
+	case f.ValidateWithMessageCallback != nil:
+		var valid bool
+		valid, msg = f.ValidateWithMessageCallback()
+		invalid = !valid
+	case f.ValidateCallback != nil:
 		invalid = !f.ValidateCallback()
 	}
+	if !invalid {
+		msg = ""
+	}
+	if msg != f.invalidMessage {
+		f.invalidMessage = msg
+		f.updateValidationTooltip()
+	}
 	if invalid != f.invalid {
 		f.invalid = invalid
 		f.MarkForRedraw()
 	}
 }
 
+// InvalidMessage returns the message supplied by the ValidateWithMessageCallback the last time the field was found to
+// be invalid. Returns an empty string if the field is valid or no message was supplied.
+func (f *Field) InvalidMessage() string {
+	return f.invalidMessage
+}
+
+func (f *Field) updateValidationTooltip() {
+	if f.invalidMessage != "" {
+		if f.Tooltip == nil || f.Tooltip == f.validationTooltip {
+			f.validationTooltip = NewTooltipWithText(f.invalidMessage)
+			f.Tooltip = f.validationTooltip
+		}
+	} else if f.validationTooltip != nil {
+		if f.Tooltip == f.validationTooltip {
+			f.Tooltip = nil
+		}
+		f.validationTooltip = nil
+	}
+}
+
 func (f *Field) sanitize(runes []rune) []rune {
 	i := 0
 	for _, ch := range runes {
@@ -1032,6 +1595,360 @@ func (f *Field) Selection() (start, end int) {
 	return f.selectionStart, f.selectionEnd
 }
 
+// CaretLineColumn returns the 1-based line and column of the caret, i.e. the end of the selection. Lines are only
+// broken by line feeds, so wrapped lines are counted as part of the line they were wrapped from.
+func (f *Field) CaretLineColumn() (line, column int) {
+	line = 1
+	lineStart := 0
+	for i, r := range f.runes[:min(f.selectionEnd, len(f.runes))] {
+		if r == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+	return line, f.selectionEnd - lineStart + 1
+}
+
+// LineCount returns the number of logical lines in the field, i.e. lines delimited by line feeds rather than by
+// wrapping. This is always at least 1.
+func (f *Field) LineCount() int {
+	count := 1
+	for _, r := range f.runes {
+		if r == '\n' {
+			count++
+		}
+	}
+	return count
+}
+
+// LineRange returns the rune indexes of the start and end of the 0-based logical line. The end is exclusive and does
+// not include the line feed that terminates the line, if any. Lines beyond the last are treated as the last line.
+func (f *Field) LineRange(line int) (start, end int) {
+	return lineRange(f.runes, line)
+}
+
+func lineRange(runes []rune, line int) (start, end int) {
+	for i, r := range runes {
+		if r == '\n' {
+			if line <= 0 {
+				return start, i
+			}
+			line--
+			start = i + 1
+		}
+	}
+	return start, len(runes)
+}
+
+// lineEndFrom returns the exclusive end of the logical line that begins at the rune index start.
+func lineEndFrom(runes []rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// LineForIndex returns the 0-based logical line containing the rune index.
+func (f *Field) LineForIndex(index int) int {
+	line := 0
+	for _, r := range f.runes[:max(min(index, len(f.runes)), 0)] {
+		if r == '\n' {
+			line++
+		}
+	}
+	return line
+}
+
+// VisualLinesForLogicalLine returns the index of the first visual line, i.e. a line as displayed after wrapping, for
+// the 0-based logical line, along with the number of visual lines it occupies. For a field that doesn't wrap, this is
+// always (line, 1) for a valid line. A count of 0 is returned for lines that don't exist or when the field is empty.
+func (f *Field) VisualLinesForLogicalLine(line int) (first, count int) {
+	f.prepareLinesForCurrentWidth()
+	if line < 0 || len(f.lines) == 0 {
+		return 0, 0
+	}
+	logical := 0
+	for i := range f.lines {
+		if logical == line {
+			if count == 0 {
+				first = i
+			}
+			count++
+		}
+		if f.endsWithLineFeed[i] == hardLineEnding {
+			logical++
+		}
+	}
+	return first, count
+}
+
+// selectedLines returns the first and last logical lines touched by the selection. A selection range that ends at the
+// start of a line does not include that line.
+func (f *Field) selectedLines() (first, last int) {
+	first = f.LineForIndex(f.selectionStart)
+	last = f.LineForIndex(f.selectionEnd)
+	if last > first && f.selectionEnd > 0 && f.runes[f.selectionEnd-1] == '\n' {
+		last--
+	}
+	return first, last
+}
+
+// replaceRunes replaces the content of the field and sets the selection, as a single undoable edit.
+func (f *Field) replaceRunes(runes []rune, start, end, anchor int) {
+	f.undoID = NextUndoID()
+	before := f.GetFieldState()
+	f.runes = runes
+	f.linesBuiltFor = -1
+	f.setSelection(start, end, anchor)
+	f.notifyOfModification(before, f.GetFieldState())
+}
+
+// shiftSelection replaces the content of the field, as a single undoable edit, moving the selection by delta runes.
+func (f *Field) shiftSelection(runes []rune, delta int) {
+	f.replaceRunes(runes, f.selectionStart+delta, f.selectionEnd+delta, f.selectionAnchor+delta)
+}
+
+// DuplicateCurrentLine inserts a copy of the logical lines touched by the selection just after them. The selection is
+// moved to the copy. Only applies to multi-line fields.
+func (f *Field) DuplicateCurrentLine() {
+	if !f.multiLine {
+		return
+	}
+	first, last := f.selectedLines()
+	start, _ := f.LineRange(first)
+	_, end := f.LineRange(last)
+	runes := make([]rune, 0, len(f.runes)+end-start+1)
+	runes = append(runes, f.runes[:end]...)
+	runes = append(runes, '\n')
+	runes = append(runes, f.runes[start:end]...)
+	runes = append(runes, f.runes[end:]...)
+	f.shiftSelection(runes, end-start+1)
+}
+
+// DeleteCurrentLine removes the logical lines touched by the selection, along with their line ending. The caret is
+// placed on the line that follows, or on the preceding line if the last line was removed, keeping its column where
+// possible. Only applies to multi-line fields.
+func (f *Field) DeleteCurrentLine() {
+	if !f.multiLine || len(f.runes) == 0 {
+		return
+	}
+	first, last := f.selectedLines()
+	start, _ := f.LineRange(first)
+	_, end := f.LineRange(last)
+	column := f.selectionStart - start
+	if end < len(f.runes) {
+		end++
+	} else if start > 0 {
+		start--
+		first--
+	}
+	runes := make([]rune, 0, len(f.runes)-(end-start))
+	runes = append(runes, f.runes[:start]...)
+	runes = append(runes, f.runes[end:]...)
+	lineStart, lineEnd := lineRange(runes, first)
+	pos := lineStart + min(column, lineEnd-lineStart)
+	f.replaceRunes(runes, pos, pos, pos)
+}
+
+// editLineStarts applies an edit to the start of each logical line touched by the selection, as a single undoable
+// edit. For each line, edit returns the number of runes to remove from the start of the line and the runes to insert
+// in their place. The selection is adjusted so that it continues to cover the same text.
+func (f *Field) editLineStarts(edit func(line []rune) (remove int, insert []rune)) {
+	first, last := f.selectedLines()
+	hasRange := f.HasSelectionRange()
+	selStart := f.selectionStart
+	selEnd := f.selectionEnd
+	runes := make([]rune, 0, len(f.runes))
+	pos := 0
+	changed := false
+	lineStart, _ := f.LineRange(first)
+	var lineEnd int
+	for line := first; line <= last; line, lineStart = line+1, lineEnd+1 {
+		lineEnd = lineEndFrom(f.runes, lineStart)
+		remove, insert := edit(f.runes[lineStart:lineEnd])
+		if remove == 0 && len(insert) == 0 {
+			continue
+		}
+		changed = true
+		runes = append(runes, f.runes[pos:lineStart]...)
+		newLineStart := len(runes)
+		runes = append(runes, insert...)
+		pos = lineStart + remove
+		delta := len(insert) - remove
+		adjust := func(old, current int, isRangeStart bool) int {
+			switch {
+			case old < lineStart:
+				return current
+			case old == lineStart && isRangeStart:
+				return newLineStart
+			case old < pos:
+				return newLineStart + len(insert)
+			default:
+				return current + delta
+			}
+		}
+		selStart = adjust(f.selectionStart, selStart, hasRange)
+		selEnd = adjust(f.selectionEnd, selEnd, false)
+	}
+	if !changed {
+		return
+	}
+	runes = append(runes, f.runes[pos:]...)
+	anchor := selEnd
+	if f.selectionAnchor == f.selectionStart {
+		anchor = selStart
+	}
+	f.replaceRunes(runes, selStart, selEnd, anchor)
+}
+
+func (f *Field) tabWidth() int {
+	if f.TabWidth < 1 {
+		return 4
+	}
+	return f.TabWidth
+}
+
+// indentRunes returns the runes that make up a single level of indentation.
+func (f *Field) indentRunes() []rune {
+	if f.TabInsertsSpaces {
+		return []rune(strings.Repeat(" ", f.tabWidth()))
+	}
+	return []rune{'\t'}
+}
+
+// insertIndent replaces the selection with a single level of indentation.
+func (f *Field) insertIndent() {
+	indent := f.indentRunes()
+	runes := make([]rune, 0, len(f.runes)+len(indent))
+	runes = append(runes, f.runes[:f.selectionStart]...)
+	runes = append(runes, indent...)
+	runes = append(runes, f.runes[f.selectionEnd:]...)
+	pos := f.selectionStart + len(indent)
+	f.replaceRunes(runes, pos, pos, pos)
+}
+
+// IndentSelection adds a level of indentation to the start of each logical line touched by the selection. Empty lines
+// are left alone when more than one line is affected. Only applies to multi-line fields.
+func (f *Field) IndentSelection() {
+	if !f.multiLine {
+		return
+	}
+	first, last := f.selectedLines()
+	indent := f.indentRunes()
+	f.editLineStarts(func(line []rune) (remove int, insert []rune) {
+		if len(line) == 0 && first != last {
+			return 0, nil
+		}
+		return 0, indent
+	})
+}
+
+// OutdentSelection removes a level of indentation, if present, from the start of each logical line touched by the
+// selection. A level is either a single tab or up to TabWidth spaces. Only applies to multi-line fields.
+func (f *Field) OutdentSelection() {
+	if !f.multiLine {
+		return
+	}
+	tabWidth := f.tabWidth()
+	f.editLineStarts(func(line []rune) (remove int, insert []rune) {
+		if len(line) != 0 && line[0] == '\t' {
+			return 1, nil
+		}
+		for remove < min(len(line), tabWidth) && line[remove] == ' ' {
+			remove++
+		}
+		return remove, nil
+	})
+}
+
+// ToggleLinePrefix adds the prefix to the start of each logical line touched by the selection, unless all of those
+// lines already begin with it, in which case it is removed from them instead. Empty lines are left alone when more than
+// one line is affected. This is useful for commands such as toggling line comments. Only applies to multi-line fields.
+func (f *Field) ToggleLinePrefix(prefix string) {
+	if !f.multiLine || prefix == "" {
+		return
+	}
+	first, last := f.selectedLines()
+	skip := func(line []rune) bool { return len(line) == 0 && first != last }
+	runes := []rune(prefix)
+	remove := true
+	start, _ := f.LineRange(first)
+	var end int
+	for line := first; line <= last; line, start = line+1, end+1 {
+		end = lineEndFrom(f.runes, start)
+		if lineRunes := f.runes[start:end]; !skip(lineRunes) && !hasRunePrefix(lineRunes, runes) {
+			remove = false
+			break
+		}
+	}
+	f.editLineStarts(func(line []rune) (int, []rune) {
+		switch {
+		case skip(line):
+			return 0, nil
+		case remove:
+			return len(runes), nil
+		default:
+			return 0, runes
+		}
+	})
+}
+
+func hasRunePrefix(runes, prefix []rune) bool {
+	return len(runes) >= len(prefix) && txt.RunesEqual(runes[:len(prefix)], prefix)
+}
+
+// CanMoveLineUp returns true if the logical lines touched by the selection can be moved up.
+func (f *Field) CanMoveLineUp() bool {
+	first, _ := f.selectedLines()
+	return f.multiLine && first > 0
+}
+
+// MoveLineUp swaps the logical lines touched by the selection with the line above them. The selection moves with the
+// lines. Only applies to multi-line fields.
+func (f *Field) MoveLineUp() {
+	if !f.CanMoveLineUp() {
+		return
+	}
+	first, last := f.selectedLines()
+	prevStart, prevEnd := f.LineRange(first - 1)
+	start, _ := f.LineRange(first)
+	_, end := f.LineRange(last)
+	runes := make([]rune, 0, len(f.runes))
+	runes = append(runes, f.runes[:prevStart]...)
+	runes = append(runes, f.runes[start:end]...)
+	runes = append(runes, '\n')
+	runes = append(runes, f.runes[prevStart:prevEnd]...)
+	runes = append(runes, f.runes[end:]...)
+	f.shiftSelection(runes, prevStart-start)
+}
+
+// CanMoveLineDown returns true if the logical lines touched by the selection can be moved down.
+func (f *Field) CanMoveLineDown() bool {
+	_, last := f.selectedLines()
+	return f.multiLine && last < f.LineCount()-1
+}
+
+// MoveLineDown swaps the logical lines touched by the selection with the line below them. The selection moves with the
+// lines. Only applies to multi-line fields.
+func (f *Field) MoveLineDown() {
+	if !f.CanMoveLineDown() {
+		return
+	}
+	first, last := f.selectedLines()
+	start, _ := f.LineRange(first)
+	_, end := f.LineRange(last)
+	nextStart, nextEnd := f.LineRange(last + 1)
+	runes := make([]rune, 0, len(f.runes))
+	runes = append(runes, f.runes[:start]...)
+	runes = append(runes, f.runes[nextStart:nextEnd]...)
+	runes = append(runes, '\n')
+	runes = append(runes, f.runes[start:end]...)
+	runes = append(runes, f.runes[nextEnd:]...)
+	f.shiftSelection(runes, nextEnd-nextStart+1)
+}
+
 // SetSelectionToStart moves the cursor to the beginning of the text and removes any range that may have been present.
 func (f *Field) SetSelectionToStart() {
 	f.SetSelection(0, 0)
@@ -1079,6 +1996,9 @@ func (f *Field) setSelection(start, end, anchor int) {
 		f.showCursor = true
 		f.MarkForRedraw()
 		f.ScrollSelectionIntoView()
+		if f.SelectionChangedCallback != nil {
+			toolbox.Call(func() { f.SelectionChangedCallback(start, end, anchor) })
+		}
 	}
 }
 
@@ -1095,6 +2015,35 @@ func (f *Field) ScrollSelectionIntoView() {
 	f.ScrollRectIntoView(Rect{Point: Point{X: pt.X - 1, Y: pt.Y}, Size: Size{Width: 3, Height: f.lineHeightAt(pt.Y)}})
 }
 
+// ScrollToIndex adjusts the autoscroll offset so that the rune at the index is visible, with its line placed at the
+// top, middle, or bottom of the content area, as specified by vAlign. The selection is not changed. Has no effect if
+// AutoScroll is false.
+func (f *Field) ScrollToIndex(index int, vAlign align.Enum) {
+	if !f.AutoScroll {
+		return
+	}
+	rect := f.ContentRect(false)
+	pt := f.FromSelectionIndex(index)
+	offset := f.scrollOffset
+	if pt.X < rect.X || pt.X >= rect.Right() {
+		offset.X = f.clampScrollOffsetX(offset.X+rect.CenterX()-pt.X, rect)
+	}
+	if f.multiLine {
+		lineHeight := f.lineHeightAt(pt.Y)
+		var top float32
+		switch vAlign {
+		case align.Middle:
+			top = rect.Y + (rect.Height-lineHeight)/2
+		case align.End:
+			top = rect.Bottom() - lineHeight
+		default:
+			top = rect.Y
+		}
+		offset.Y = f.clampScrollOffsetY(offset.Y+top-pt.Y, rect)
+	}
+	f.SetScrollOffset(offset)
+}
+
 // ScrollOffset returns the current autoscroll offset.
 func (f *Field) ScrollOffset() Point {
 	return f.scrollOffset
@@ -1161,30 +2110,79 @@ func (f *Field) autoScroll() {
 				}
 			}
 		}
-		save := f.scrollOffset.Y
-		f.scrollOffset.Y = 0
-		top := f.FromSelectionIndex(len(f.runes)).Y
-		minimum := rect.Bottom() - (top + f.lineHeightAt(top))
-		if minimum > 0 {
-			minimum = 0
-		}
-		top = f.FromSelectionIndex(0).Y
-		maximum := rect.Y - (top + f.lineHeightAt(top))
-		if maximum < 0 {
-			maximum = 0
-		}
-		if save < minimum {
-			save = minimum
-		} else if save > maximum {
-			save = maximum
-		}
-		f.scrollOffset.Y = save
+		f.scrollOffset.Y = f.clampScrollOffsetY(f.scrollOffset.Y, rect)
 	}
 	if original != f.scrollOffset {
 		f.MarkForRedraw()
 	}
 }
 
+// clampScrollOffsetY returns the vertical scroll offset constrained such that no more empty space than necessary is
+// shown beyond the text.
+func (f *Field) clampScrollOffsetY(y float32, rect Rect) float32 {
+	save := f.scrollOffset.Y
+	f.scrollOffset.Y = 0
+	top := f.FromSelectionIndex(len(f.runes)).Y
+	minimum := rect.Bottom() - (top + f.lineHeightAt(top))
+	if minimum > 0 {
+		minimum = 0
+	}
+	top = f.FromSelectionIndex(0).Y
+	maximum := rect.Y - (top + f.lineHeightAt(top))
+	if maximum < 0 {
+		maximum = 0
+	}
+	f.scrollOffset.Y = save
+	return min(max(y, minimum), maximum)
+}
+
+// clampScrollOffsetX returns the horizontal scroll offset constrained such that the widest line can be panned
+// through, but no further. When all lines fit within the content width, this is always zero.
+func (f *Field) clampScrollOffsetX(x float32, rect Rect) float32 {
+	f.prepareLines(rect.Width - 2*f.caretWidth())
+	var widest *Text
+	for _, line := range f.lines {
+		if widest == nil || line.Width() > widest.Width() {
+			widest = line
+		}
+	}
+	caretWidth := f.caretWidth()
+	if widest == nil || widest.Width() <= rect.Width-2*caretWidth {
+		return 0
+	}
+	left := f.textLeft(widest, rect)
+	return min(max(x, rect.Right()-caretWidth-widest.Width()-left), rect.X+caretWidth-left)
+}
+
+// DefaultMouseWheel provides the default mouse wheel handling, panning the text when it doesn't fit within the field.
+// Holding the shift key while using a wheel that only scrolls vertically pans horizontally instead. Returns false, so
+// that an enclosing panel may handle the event, if the text could not be panned any further in the requested direction.
+func (f *Field) DefaultMouseWheel(_, delta Point, mod Modifiers) bool {
+	if !f.AutoScroll {
+		return false
+	}
+	if mod.ShiftDown() && delta.X == 0 {
+		delta.X, delta.Y = delta.Y, 0
+	}
+	if MouseWheelMultiplier > 0 {
+		delta = delta.Mul(MouseWheelMultiplier)
+	}
+	rect := f.ContentRect(false)
+	offset := f.scrollOffset
+	if delta.X != 0 {
+		offset.X = f.clampScrollOffsetX(offset.X+delta.X, rect)
+	}
+	if delta.Y != 0 && f.multiLine {
+		offset.Y = f.clampScrollOffsetY(offset.Y+delta.Y, rect)
+	}
+	if offset == f.scrollOffset {
+		return false
+	}
+	f.scrollOffset = offset
+	f.MarkForRedraw()
+	return true
+}
+
 func (f *Field) textLeft(text *Text, bounds Rect) float32 {
 	return f.textLeftForWidth(text.Width(), bounds)
 }
@@ -1195,22 +2193,31 @@ func (f *Field) textLeftForWidth(width float32, bounds Rect) float32 {
 	case align.Middle:
 		left += (bounds.Width - width) / 2
 	case align.End:
-		left += bounds.Width - width - 1 // Inset since we leave space for the cursor
+		left += bounds.Width - width - f.caretWidth() // Inset since we leave space for the cursor
 	default:
-		left++ // Inset since we leave space for the cursor
+		left += f.caretWidth() // Inset since we leave space for the cursor
 	}
 	return left
 }
 
+func (f *Field) caretWidth() float32 {
+	return max(f.CaretWidth, 1)
+}
+
 // ToSelectionIndex returns the rune index for the coordinates.
 func (f *Field) ToSelectionIndex(where Point) int {
+	return f.toSelectionIndex(where, rounding.Nearest)
+}
+
+func (f *Field) toSelectionIndex(where Point, mode rounding.Enum) int {
 	if len(f.runes) == 0 {
 		return 0
 	}
 	f.prepareLinesForCurrentWidth()
 	lineIndex, start := f.lineIndexForY(where.Y)
 	line := f.lines[lineIndex]
-	return start + line.RuneIndexForPosition(where.X-(f.textLeft(line, f.ContentRect(false))+f.scrollOffset.X))
+	return start + line.RuneIndexForPositionWithRounding(where.X-(f.textLeft(line, f.ContentRect(false))+f.scrollOffset.X),
+		mode)
 }
 
 // FromSelectionIndex returns a location in local coordinates for the specified rune index.
@@ -1229,7 +2236,7 @@ func (f *Field) FromSelectionIndex(index int) Point {
 		if !f.multiLine || index < start+length {
 			return Point{X: f.textLeft(line, rect) + line.PositionForRuneIndex(index-start) + f.scrollOffset.X, Y: y}
 		}
-		lastHeight = max(line.Height(), f.Font.LineHeight())
+		lastHeight = max(line.Height(), f.textFont().LineHeight())
 		y += lastHeight
 		start += length
 	}
@@ -1246,19 +2253,54 @@ func (f *Field) findWordAt(pos int) (start, end int) {
 	start = pos
 	end = pos
 	if length > 0 && f.isWordPart(start) {
-		for start > 0 && f.isWordPart(start-1) {
+		breaker := f.WordBreaker()
+		for start > 0 && f.isWordPart(start-1) && !breaker.IsWordBoundary(f.runes, start) {
 			start--
 		}
-		for end < length && f.isWordPart(end) {
+		end++
+		for end < length && f.isWordPart(end) && !breaker.IsWordBoundary(f.runes, end) {
 			end++
 		}
 	}
 	return start, end
 }
 
+// DefaultClickSelectionBehavior provides the default mapping of click counts to selection granularity: a double-click
+// selects a word, a triple-click selects everything, and any other click count just positions the caret.
+func DefaultClickSelectionBehavior(clickCount int) granularity.Enum {
+	switch clickCount {
+	case 2:
+		return granularity.Word
+	case 3:
+		return granularity.Everything
+	default:
+		return granularity.Position
+	}
+}
+
+// SelectParagraphAt selects the paragraph containing the given rune index. A paragraph is delimited by line feeds, so
+// for single-line fields, this selects all of the text. The trailing line feed, if any, is not included.
+func (f *Field) SelectParagraphAt(pos int) {
+	start, end := f.findParagraphAt(pos)
+	f.SetSelection(start, end)
+}
+
+func (f *Field) findParagraphAt(pos int) (start, end int) {
+	length := len(f.runes)
+	pos = max(min(pos, length), 0)
+	start = pos
+	for start > 0 && f.runes[start-1] != '\n' {
+		start--
+	}
+	end = pos
+	for end < length && f.runes[end] != '\n' {
+		end++
+	}
+	return start, end
+}
+
 func (f *Field) isWordPart(index int) bool {
-	r := f.runes[index]
-	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+	return f.WordBreaker().IsWordPart(f.runes, index)
 }
 
 func (f *Field) findPrevLineBreak(pos int) int {
@@ -1315,7 +2357,7 @@ func (f *Field) lineIndexForY(y float32) (index, startPos int) {
 	start := 0
 	length := 0
 	for i, line := range f.lines {
-		lineHeight := max(line.Height(), f.Font.LineHeight())
+		lineHeight := max(line.Height(), f.textFont().LineHeight())
 		if y >= offsetY && y <= offsetY+lineHeight {
 			return i, start
 		}
@@ -1348,6 +2390,52 @@ func (f *Field) ApplyFieldState(state *FieldState) {
 	if !txt.RunesEqual(runes, f.runes) {
 		f.runes = runes
 		f.linesBuiltFor = -1
+		f.clickableRanges = nil
 	}
 	f.setSelection(state.SelectionStart, state.SelectionEnd, state.SelectionAnchor)
 }
+
+// AddClickableRange marks the runes from start up to, but not including, end as clickable. A left click within the
+// range will call onClick rather than altering the selection. If cursor is not nil, it will be shown while the mouse is
+// over the range. Clickable ranges are removed whenever the text is changed.
+func (f *Field) AddClickableRange(start, end int, onClick func(), cursor *Cursor) {
+	start = max(start, 0)
+	end = min(end, len(f.runes))
+	if start < end {
+		f.clickableRanges = append(f.clickableRanges, fieldClickableRange{
+			start:   start,
+			end:     end,
+			onClick: onClick,
+			cursor:  cursor,
+		})
+	}
+}
+
+// ClearClickableRanges removes all clickable ranges.
+func (f *Field) ClearClickableRanges() {
+	f.clickableRanges = nil
+}
+
+func (f *Field) clickableRangeAt(where Point) *fieldClickableRange {
+	if len(f.clickableRanges) == 0 || len(f.runes) == 0 {
+		return nil
+	}
+	index := f.toSelectionIndex(where, rounding.Floor)
+	if index >= len(f.runes) {
+		return nil
+	}
+	// Make sure the point is actually over the rune and not in the empty area beyond the end of its line
+	pt := f.FromSelectionIndex(index)
+	if where.X < pt.X || where.Y < pt.Y || where.Y >= pt.Y+f.lineHeightAt(pt.Y) {
+		return nil
+	}
+	if next := f.FromSelectionIndex(index + 1); next.Y == pt.Y && where.X >= next.X {
+		return nil
+	}
+	for i := range f.clickableRanges {
+		if r := &f.clickableRanges[i]; index >= r.start && index < r.end {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestFieldSetWrap(t *testing.T) {
+	f := unison.NewMultiLineField()
+	f.SetText("The quick brown fox jumps over the lazy dog")
+	f.SetSelectionTo(10)
+	check.True(t, f.Wrap())
+
+	f.SetWrap(false)
+	check.False(t, f.Wrap())
+	start, end := f.Selection()
+	check.Equal(t, 10, start)
+	check.Equal(t, 10, end)
+
+	f.SetWrap(true)
+	check.True(t, f.Wrap())
+	start, end = f.Selection()
+	check.Equal(t, 10, start)
+	check.Equal(t, 10, end)
+}
+
+func TestFieldObscuresGraphemeClusters(t *testing.T) {
+	f := unison.NewField()
+	f.ObscurementRune = '*'
+	for _, one := range []struct {
+		name     string
+		text     string
+		clusters int
+	}{
+		{name: "plain", text: "abc", clusters: 3},
+		{name: "zwj sequence", text: "\U0001F468\u200D\U0001F469\u200D\U0001F467", clusters: 1},
+		{name: "skin tone and trailing zwj", text: "\U0001F44D\U0001F3FD\u200D", clusters: 1},
+		{name: "flag", text: "\U0001F1FA\U0001F1F8", clusters: 1},
+		{name: "two flags", text: "\U0001F1FA\U0001F1F8\U0001F1EC\U0001F1E7", clusters: 2},
+		{name: "flag and lone indicator", text: "\U0001F1FA\U0001F1F8\U0001F1EC", clusters: 2},
+		{name: "combining marks", text: "e\u0301a\u0308\u0323", clusters: 2},
+		{name: "spacing mark", text: "\u0915\u093F", clusters: 1},
+		{name: "variation selector", text: "\u2764\uFE0F!", clusters: 2},
+		{name: "tags", text: "\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", clusters: 1},
+	} {
+		f.SetText(one.text)
+		value := f.DefaultAccessibility().Value
+		check.Equal(t, len([]rune(one.text)), len([]rune(value)), one.name)
+		check.Equal(t, one.clusters, strings.Count(value, "*"), one.name)
+	}
+}
+
+// runeWordBreaker only allows lines to be wrapped after the runes it holds. Since it holds a slice, it isn't
+// comparable.
+type runeWordBreaker struct {
+	unison.StandardWordBreaker
+	breakAfter []rune
+}
+
+func (b runeWordBreaker) CanBreakAfter(runes []rune, index int) bool {
+	return slices.Contains(b.breakAfter, runes[index])
+}
+
+func fieldLineStrings(f *unison.Field, width float32) []string {
+	lines := unison.FieldLines(f, width)
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = line.String()
+	}
+	return result
+}
+
+func TestFieldReusesUnchangedLines(t *testing.T) {
+	const width = 60
+	f := unison.NewMultiLineField()
+	f.SetText("alpha beta gamma delta\nsecond line of text\nthird and final line")
+	before := unison.FieldLines(f, width)
+	check.True(t, len(before) > 3)
+	first := before[0]
+	last := before[len(before)-1]
+
+	f.SetText("alpha beta gamma delta\nan edited second line\nthird and final line")
+	after := unison.FieldLines(f, width)
+	check.True(t, first == after[0])
+	check.True(t, last == after[len(after)-1])
+
+	fresh := unison.NewMultiLineField()
+	fresh.SetText(f.Text())
+	check.Equal(t, fieldLineStrings(fresh, width), fieldLineStrings(f, width))
+
+	f.SetWordBreaker(runeWordBreaker{})
+	fresh = unison.NewMultiLineField()
+	fresh.SetWordBreaker(runeWordBreaker{})
+	fresh.SetText(f.Text())
+	check.Equal(t, fieldLineStrings(fresh, width), fieldLineStrings(f, width))
+	check.Equal(t, []string{"alpha beta gamma delta", "an edited second line", "third and final line"},
+		fieldLineStrings(f, width))
+}
+
+func TestFieldLineRange(t *testing.T) {
+	f := unison.NewMultiLineField()
+	check.Equal(t, 1, f.LineCount())
+	f.SetText("one\ntwo\n\nfour")
+	check.Equal(t, 4, f.LineCount())
+	start, end := f.LineRange(0)
+	check.Equal(t, 0, start)
+	check.Equal(t, 3, end)
+	start, end = f.LineRange(2)
+	check.Equal(t, 8, start)
+	check.Equal(t, 8, end)
+	start, end = f.LineRange(3)
+	check.Equal(t, 9, start)
+	check.Equal(t, 13, end)
+	check.Equal(t, 0, f.LineForIndex(3))
+	check.Equal(t, 1, f.LineForIndex(4))
+	check.Equal(t, 3, f.LineForIndex(100))
+}
+
+func TestFieldLineEditing(t *testing.T) {
+	f := unison.NewMultiLineField()
+	f.SetText("one\ntwo\nthree")
+	f.SetSelectionTo(5)
+
+	f.DuplicateCurrentLine()
+	check.Equal(t, "one\ntwo\ntwo\nthree", f.Text())
+	start, end := f.Selection()
+	check.Equal(t, 9, start)
+	check.Equal(t, 9, end)
+
+	f.MoveLineUp()
+	check.Equal(t, "one\ntwo\ntwo\nthree", f.Text())
+	start, _ = f.Selection()
+	check.Equal(t, 5, start)
+
+	f.SetSelectionTo(13)
+	f.MoveLineUp()
+	check.Equal(t, "one\ntwo\nthree\ntwo", f.Text())
+	start, _ = f.Selection()
+	check.Equal(t, 9, start)
+
+	f.MoveLineDown()
+	check.Equal(t, "one\ntwo\ntwo\nthree", f.Text())
+	check.False(t, f.CanMoveLineDown())
+
+	f.DeleteCurrentLine()
+	check.Equal(t, "one\ntwo\ntwo", f.Text())
+	start, _ = f.Selection()
+	check.Equal(t, 9, start)
+
+	f.SetSelectionTo(1)
+	f.DeleteCurrentLine()
+	check.Equal(t, "two\ntwo", f.Text())
+	start, _ = f.Selection()
+	check.Equal(t, 1, start)
+}
+
+func TestFieldIndentSelection(t *testing.T) {
+	f := unison.NewMultiLineField()
+	f.TabInsertsSpaces = true
+	f.TabWidth = 2
+	f.SetText("one\n\ntwo\nthree")
+	f.SetSelection(1, 7)
+	f.IndentSelection()
+	check.Equal(t, "  one\n\n  two\nthree", f.Text())
+	start, end := f.Selection()
+	check.Equal(t, 3, start)
+	check.Equal(t, 11, end)
+
+	f.OutdentSelection()
+	check.Equal(t, "one\n\ntwo\nthree", f.Text())
+	start, end = f.Selection()
+	check.Equal(t, 1, start)
+	check.Equal(t, 7, end)
+
+	f.SetSelection(0, 4)
+	f.IndentSelection()
+	check.Equal(t, "  one\n\ntwo\nthree", f.Text())
+	start, end = f.Selection()
+	check.Equal(t, 0, start)
+	check.Equal(t, 6, end)
+}
+
+func TestFieldToggleLinePrefix(t *testing.T) {
+	f := unison.NewMultiLineField()
+	f.SetText("one\n\n// two\nthree")
+	f.SetSelection(0, len(f.Text()))
+	f.ToggleLinePrefix("// ")
+	check.Equal(t, "// one\n\n// // two\n// three", f.Text())
+	start, end := f.Selection()
+	check.Equal(t, 0, start)
+	check.Equal(t, len(f.Text()), end)
+
+	f.ToggleLinePrefix("// ")
+	check.Equal(t, "one\n\n// two\nthree", f.Text())
+
+	f.SetSelectionTo(7)
+	f.ToggleLinePrefix("// ")
+	check.Equal(t, "one\n\ntwo\nthree", f.Text())
+	start, _ = f.Selection()
+	check.Equal(t, 5, start)
+}
+
+func TestFieldMatchingBracket(t *testing.T) {
+	f := unison.NewMultiLineField()
+	f.SetText("f(a[1], {b})\n(]")
+	i, ok := f.MatchingBracket(1)
+	check.True(t, ok)
+	check.Equal(t, 11, i)
+	i, ok = f.MatchingBracket(11)
+	check.True(t, ok)
+	check.Equal(t, 1, i)
+	i, ok = f.MatchingBracket(8)
+	check.True(t, ok)
+	check.Equal(t, 10, i)
+	_, ok = f.MatchingBracket(0)
+	check.False(t, ok)
+	_, ok = f.MatchingBracket(13)
+	check.False(t, ok)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
+)
+
+// DefaultFocusRingTheme holds the default FocusRingTheme values used when drawing the focus ring. Modifying this data
+// takes effect the next time a window is drawn.
+var DefaultFocusRingTheme = FocusRingTheme{
+	Ink:          ThemeFocus,
+	Thickness:    2,
+	Outset:       2,
+	CornerRadius: 4,
+}
+
+// ShowFocusRing causes a focus ring, as described by DefaultFocusRingTheme, to be drawn around the panel that has the
+// keyboard focus, in addition to any focus indication the panel draws itself.
+var ShowFocusRing bool
+
+// FocusRingTheme holds theming data for the focus ring.
+type FocusRingTheme struct {
+	// Ink is used to stroke the ring.
+	Ink Ink
+	// Drawable, if set, is drawn into the ring's bounds, using Ink as its paint, instead of stroking the ring.
+	Drawable     Drawable
+	Thickness    float32
+	Outset       float32
+	CornerRadius float32
+}
+
+// DrawFocusRing draws the focus ring around the panel, which must be within the window being drawn. The canvas must
+// be in the coordinate system of the window's root panel.
+func (t *FocusRingTheme) DrawFocusRing(gc *Canvas, panel *Panel) {
+	visible := panel.RectToRoot(panel.ContentRect(true))
+	rect := visible.Inset(NewUniformInsets(-(t.Outset + t.Thickness/2)))
+	for p := panel.Parent(); p != nil; p = p.Parent() {
+		visible = visible.Intersect(p.RectToRoot(p.ContentRect(true)))
+	}
+	if visible.Empty() {
+		return
+	}
+	gc.Save()
+	defer gc.Restore()
+	gc.ClipRect(visible.Inset(NewUniformInsets(-(t.Outset + t.Thickness))), pathop.Intersect, false)
+	if t.Drawable != nil {
+		var paint *Paint
+		if t.Ink != nil {
+			paint = t.Ink.Paint(gc, rect, paintstyle.Fill)
+		}
+		t.Drawable.DrawInRect(gc, rect, nil, paint)
+		return
+	}
+	if t.Ink == nil || t.Thickness <= 0 {
+		return
+	}
+	p := t.Ink.Paint(gc, rect, paintstyle.Stroke)
+	p.SetStrokeWidth(t.Thickness)
+	gc.DrawRoundedRect(rect, t.CornerRadius, t.CornerRadius, p)
+}
+
+// DrawFocusRect draws the ring within the rectangle, rather than around a panel, for panels that draw their own focus
+// indication. If ink is nil, the theme's Ink is used.
+func (t *FocusRingTheme) DrawFocusRect(gc *Canvas, rect Rect, ink Ink) {
+	if ink == nil {
+		ink = t.Ink
+	}
+	if t.Drawable != nil {
+		var paint *Paint
+		if ink != nil {
+			paint = ink.Paint(gc, rect, paintstyle.Fill)
+		}
+		t.Drawable.DrawInRect(gc, rect, nil, paint)
+		return
+	}
+	if ink == nil || t.Thickness <= 0 {
+		return
+	}
+	indicator := IndicatorTheme{StrokeWidth: t.Thickness, CornerRadius: t.CornerRadius, MiterLimit: 4}
+	indicator.Draw(gc, rect, ink)
+}
@@ -177,6 +177,17 @@ func main() {
 			{Key: "linear", Comment: "Interpolate between 2x2 sample points (bilinear interpolation)"},
 		},
 	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "enums/granularity",
+		Name: "granularity",
+		Desc: "holds the amount of text a mouse click selects",
+		Values: []enumValue{
+			{Key: "position", Comment: "Place the caret at the click position"},
+			{Key: "word", Comment: "Select the word at the click position"},
+			{Key: "paragraph", Comment: "Select the paragraph (line-feed delimited) at the click position"},
+			{Key: "everything", Comment: "Select all of the text"},
+		},
+	})
 	processSourceTemplate(enumTmpl, &enumInfo{
 		Pkg:  "enums/imgfmt",
 		Name: "imgfmt",
@@ -255,6 +266,36 @@ func main() {
 			{Key: "polygon"},
 		},
 	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "enums/role",
+		Name: "role",
+		Desc: "holds the role a panel plays, as reported to assistive technologies",
+		Values: []enumValue{
+			{Key: "none", Comment: "No specific role; the panel is not reported on its own"},
+			{Key: "group", Comment: "A container of other panels"},
+			{Key: "label", Comment: "Static text"},
+			{Key: "image", Comment: "A static image"},
+			{Key: "button", Comment: "A button that performs an action when clicked"},
+			{Key: "check-box", Comment: "A button that toggles a checked state"},
+			{Key: "radio-button", Comment: "A button that is one of a mutually exclusive set"},
+			{Key: "text-field", Comment: "An editable text field"},
+			{Key: "popup-menu", Comment: "A button that presents a menu of choices"},
+			{Key: "menu-item", Comment: "An item within a menu"},
+			{Key: "list", Comment: "A list of selectable items"},
+			{Key: "slider", Comment: "A control for choosing a value from a range"},
+			{Key: "progress-bar", Comment: "An indicator of the progress of an operation"},
+		},
+	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "enums/rounding",
+		Name: "rounding",
+		Desc: "controls how a position that falls within a rune is resolved to a rune index",
+		Values: []enumValue{
+			{Key: "nearest", Comment: "Use the nearest rune boundary"},
+			{Key: "floor", Comment: "Use the start of the rune the position falls within"},
+			{Key: "ceil", Comment: "Use the end of the rune the position falls within"},
+		},
+	})
 	processSourceTemplate(enumTmpl, &enumInfo{
 		Pkg:  "enums/side",
 		Name: "side",
@@ -313,6 +354,15 @@ func main() {
 			{Key: "bevel"},
 		},
 	})
+	processSourceTemplate(enumTmpl, &enumInfo{
+		Pkg:  "enums/svgdrawmode",
+		Name: "svgdrawmode",
+		Desc: "controls how the paths of an SVG are drawn",
+		Values: []enumValue{
+			{Key: "combined", Comment: "Draw all paths as a single, combined path"},
+			{Key: "per-path", Comment: "Draw each path individually, applying any per-element overrides"},
+		},
+	})
 	processSourceTemplate(enumTmpl, &enumInfo{
 		Pkg:  "enums/thememode",
 		Name: "thememode",
@@ -30,13 +30,15 @@ func (s Stop) String() string {
 
 // Gradient defines a smooth transition between colors across an area. Start and End should hold values from 0 to 1.
 // These will be be used to set a relative starting and ending position for the gradient. If StartRadius and EndRadius
-// are both greater than 0, then the gradient will be a radial one instead of a linear one.
+// are both greater than 0, then the gradient will be a radial one instead of a linear one. TileMode determines how the
+// area beyond the start and end of the gradient is filled.
 type Gradient struct {
 	Stops       []Stop
 	Start       Point
 	StartRadius float32
 	End         Point
 	EndRadius   float32
+	TileMode    tilemode.Enum
 }
 
 // NewHorizontalEvenlySpacedGradient creates a new gradient with the specified colors evenly spread across the whole
@@ -106,10 +108,10 @@ func (g *Gradient) Paint(_ *Canvas, rect Rect, style paintstyle.Enum) *Paint {
 	}
 	var shader *Shader
 	if g.StartRadius > 0 && g.EndRadius > 0 {
-		shader = New2PtConicalGradientShader(start, end, g.StartRadius, g.EndRadius, colors, colorPos, tilemode.Clamp,
+		shader = New2PtConicalGradientShader(start, end, g.StartRadius, g.EndRadius, colors, colorPos, g.TileMode,
 			NewIdentityMatrix())
 	} else {
-		shader = NewLinearGradientShader(start, end, colors, colorPos, tilemode.Clamp, NewIdentityMatrix())
+		shader = NewLinearGradientShader(start, end, colors, colorPos, g.TileMode, NewIdentityMatrix())
 	}
 	paint.SetShader(shader)
 	return paint
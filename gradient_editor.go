@@ -0,0 +1,415 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
+)
+
+// DefaultGradientEditorTheme holds the default GradientEditorTheme values for GradientEditors. Modifying this data will
+// not alter existing GradientEditors, but will alter any GradientEditors created in the future.
+var DefaultGradientEditorTheme = GradientEditorTheme{
+	EdgeInk:      ThemeSurfaceEdge,
+	SelectionInk: ThemeFocus,
+	StripHeight:  20,
+	HandleSize:   12,
+	CornerRadius: 4,
+	MinimumWidth: 200,
+	NudgeAmount:  0.01,
+	MinimumStops: 2,
+	MaximumStops: 16,
+	CheckerSize:  4,
+}
+
+// GradientEditorTheme holds theming data for a GradientEditor.
+type GradientEditorTheme struct {
+	EdgeInk      Ink
+	SelectionInk Ink
+	StripHeight  float32
+	HandleSize   float32
+	CornerRadius float32
+	MinimumWidth float32
+	NudgeAmount  float32
+	CheckerSize  float32
+	MinimumStops int
+	MaximumStops int
+}
+
+// GradientEditor provides a control for editing the color stops of a Gradient. Clicking in an empty spot adds a stop,
+// dragging a stop's handle repositions it and double-clicking a handle edits its color. When focused, the arrow keys
+// nudge the selected stop (by ten times the NudgeAmount if the shift key is held down) and Backspace or Delete
+// removes it.
+type GradientEditor struct {
+	// GradientChangedCallback is called whenever the gradient is altered.
+	GradientChangedCallback func()
+	// EditStopColorCallback is called to edit the color of the stop at the given index. Defaults to
+	// DefaultEditStopColor.
+	EditStopColorCallback func(index int)
+	gradient              Gradient
+	GradientEditorTheme
+	Panel
+	selected int
+	dragging bool
+}
+
+// NewGradientEditor creates a new GradientEditor, initialized with a horizontal black to white gradient.
+func NewGradientEditor() *GradientEditor {
+	e := &GradientEditor{GradientEditorTheme: DefaultGradientEditorTheme}
+	e.Self = e
+	e.SetFocusable(true)
+	e.SetSizer(e.DefaultSizes)
+	e.EditStopColorCallback = e.DefaultEditStopColor
+	e.DrawCallback = e.DefaultDraw
+	e.GainedFocusCallback = e.DefaultFocusGained
+	e.LostFocusCallback = e.MarkForRedraw
+	e.MouseDownCallback = e.DefaultMouseDown
+	e.MouseDragCallback = e.DefaultMouseDrag
+	e.MouseUpCallback = e.DefaultMouseUp
+	e.KeyDownCallback = e.DefaultKeyDown
+	e.UpdateCursorCallback = e.DefaultUpdateCursor
+	e.SetGradient(NewHorizontalEvenlySpacedGradient(Black, White))
+	return e
+}
+
+// Gradient returns a copy of the gradient being edited.
+func (e *GradientEditor) Gradient() *Gradient {
+	g := e.gradient
+	g.Stops = make([]Stop, len(e.gradient.Stops))
+	copy(g.Stops, e.gradient.Stops)
+	return &g
+}
+
+// SetGradient sets the gradient to edit. A copy of the gradient is made, with its stops sorted by location.
+func (e *GradientEditor) SetGradient(gradient *Gradient) {
+	if gradient == nil {
+		gradient = NewHorizontalEvenlySpacedGradient(Black, White)
+	}
+	e.gradient = *gradient
+	e.gradient.Stops = make([]Stop, len(gradient.Stops))
+	copy(e.gradient.Stops, gradient.Stops)
+	for i := range e.gradient.Stops {
+		if e.gradient.Stops[i].Color == nil {
+			e.gradient.Stops[i].Color = Black
+		}
+		e.gradient.Stops[i].Location = max(min(e.gradient.Stops[i].Location, 1), 0)
+	}
+	for i := 1; i < len(e.gradient.Stops); i++ {
+		for j := i; j > 0 && e.gradient.Stops[j].Location < e.gradient.Stops[j-1].Location; j-- {
+			e.gradient.Stops[j], e.gradient.Stops[j-1] = e.gradient.Stops[j-1], e.gradient.Stops[j]
+		}
+	}
+	e.selected = min(e.selected, len(e.gradient.Stops)-1)
+	e.MarkForRedraw()
+}
+
+// SelectedStop returns the index of the selected stop, or -1 if there are no stops.
+func (e *GradientEditor) SelectedStop() int {
+	return e.selected
+}
+
+// SetSelectedStop sets the index of the selected stop.
+func (e *GradientEditor) SetSelectedStop(index int) {
+	if index >= 0 && index < len(e.gradient.Stops) && index != e.selected {
+		e.selected = index
+		e.MarkForRedraw()
+	}
+}
+
+// AddStop adds a stop at the given location, using the color the gradient currently has at that point. Returns the
+// index of the new stop, or -1 if the MaximumStops limit has been reached.
+func (e *GradientEditor) AddStop(location float32) int {
+	if e.MaximumStops > 0 && len(e.gradient.Stops) >= e.MaximumStops {
+		return -1
+	}
+	location = max(min(location, 1), 0)
+	index := 0
+	for index < len(e.gradient.Stops) && e.gradient.Stops[index].Location <= location {
+		index++
+	}
+	e.gradient.Stops = append(e.gradient.Stops, Stop{})
+	copy(e.gradient.Stops[index+1:], e.gradient.Stops[index:])
+	e.gradient.Stops[index] = Stop{Color: e.colorAt(location), Location: location}
+	e.selected = index
+	e.notifyOfChange()
+	return index
+}
+
+// RemoveStop removes the stop at the given index. Does nothing if the removal would leave fewer than MinimumStops.
+func (e *GradientEditor) RemoveStop(index int) {
+	if index < 0 || index >= len(e.gradient.Stops) || len(e.gradient.Stops) <= max(e.MinimumStops, 1) {
+		return
+	}
+	e.gradient.Stops = append(e.gradient.Stops[:index], e.gradient.Stops[index+1:]...)
+	e.selected = max(min(e.selected, len(e.gradient.Stops)-1), 0)
+	e.notifyOfChange()
+}
+
+// SetStopColor sets the color of the stop at the given index.
+func (e *GradientEditor) SetStopColor(index int, color ColorProvider) {
+	if index >= 0 && index < len(e.gradient.Stops) && color != nil {
+		e.gradient.Stops[index].Color = color
+		e.notifyOfChange()
+	}
+}
+
+// SetStopLocation sets the location of the stop at the given index, keeping the stops ordered by location. If the
+// stop being moved was selected, it remains selected.
+func (e *GradientEditor) SetStopLocation(index int, location float32) {
+	if index < 0 || index >= len(e.gradient.Stops) {
+		return
+	}
+	stops := e.gradient.Stops
+	stops[index].Location = max(min(location, 1), 0)
+	wasSelected := index == e.selected
+	for index > 0 && stops[index].Location < stops[index-1].Location {
+		stops[index], stops[index-1] = stops[index-1], stops[index]
+		index--
+	}
+	for index < len(stops)-1 && stops[index].Location > stops[index+1].Location {
+		stops[index], stops[index+1] = stops[index+1], stops[index]
+		index++
+	}
+	if wasSelected {
+		e.selected = index
+	}
+	e.notifyOfChange()
+}
+
+func (e *GradientEditor) colorAt(location float32) ColorProvider {
+	stops := e.gradient.Stops
+	switch {
+	case len(stops) == 0:
+		return Black
+	case location <= stops[0].Location:
+		return stops[0].Color.GetColor()
+	case location >= stops[len(stops)-1].Location:
+		return stops[len(stops)-1].Color.GetColor()
+	}
+	for i := 1; i < len(stops); i++ {
+		if location <= stops[i].Location {
+			prev := stops[i-1]
+			span := stops[i].Location - prev.Location
+			if span <= 0 {
+				return stops[i].Color.GetColor()
+			}
+			c1 := prev.Color.GetColor()
+			c2 := stops[i].Color.GetColor()
+			pct := (location - prev.Location) / span
+			return c1.Blend(c2, pct).SetAlphaIntensity(c1.AlphaIntensity()*(1-pct) + c2.AlphaIntensity()*pct)
+		}
+	}
+	return stops[len(stops)-1].Color.GetColor()
+}
+
+func (e *GradientEditor) notifyOfChange() {
+	e.MarkForRedraw()
+	if e.GradientChangedCallback != nil {
+		toolbox.Call(e.GradientChangedCallback)
+	}
+}
+
+// DefaultSizes provides the default sizing.
+func (e *GradientEditor) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
+	prefSize.Width = e.MinimumWidth
+	prefSize.Height = e.StripHeight + e.HandleSize
+	if border := e.Border(); border != nil {
+		prefSize = prefSize.Add(border.Insets().Size())
+	}
+	prefSize = prefSize.Ceil().ConstrainForHint(hint)
+	return prefSize, prefSize, Size{Width: DefaultMaxSize, Height: prefSize.Height}
+}
+
+// DefaultFocusGained provides the default focus gained handling.
+func (e *GradientEditor) DefaultFocusGained() {
+	e.ScrollIntoView()
+	e.MarkForRedraw()
+}
+
+func (e *GradientEditor) stripRect() Rect {
+	r := e.ContentRect(false)
+	return NewRect(r.X+e.HandleSize/2, r.Y, max(r.Width-e.HandleSize, 0), e.StripHeight)
+}
+
+func (e *GradientEditor) handleRect(index int) Rect {
+	strip := e.stripRect()
+	x := strip.X + strip.Width*e.gradient.Stops[index].Location
+	return NewRect(x-e.HandleSize/2, strip.Bottom(), e.HandleSize, e.HandleSize)
+}
+
+func (e *GradientEditor) locationForPosition(x float32) float32 {
+	strip := e.stripRect()
+	if strip.Width <= 0 {
+		return 0
+	}
+	return max(min((x-strip.X)/strip.Width, 1), 0)
+}
+
+func (e *GradientEditor) stopAt(where Point) int {
+	// Later stops are drawn on top, so search in reverse so that the topmost handle wins
+	for i := len(e.gradient.Stops) - 1; i >= 0; i-- {
+		if r := e.handleRect(i); where.In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultDraw provides the default drawing.
+func (e *GradientEditor) DefaultDraw(canvas *Canvas, _ Rect) {
+	strip := e.stripRect()
+	canvas.Save()
+	path := NewPath()
+	path.RoundedRect(strip, e.CornerRadius, e.CornerRadius)
+	canvas.ClipPath(path, pathop.Intersect, true)
+	DrawCheckerboard(canvas, strip, e.CheckerSize, White, LightGray)
+	if len(e.gradient.Stops) != 0 {
+		g := e.gradient
+		g.Start = Point{}
+		g.End = Point{X: 1}
+		g.StartRadius = 0
+		g.EndRadius = 0
+		var ink Ink = &g
+		if !e.Enabled() {
+			ink = &ColorFilteredInk{OriginalInk: ink, ColorFilter: Grayscale30Filter()}
+		}
+		canvas.DrawRect(strip, ink.Paint(canvas, strip, paintstyle.Fill))
+	}
+	canvas.Restore()
+	canvas.DrawRoundedRect(strip, e.CornerRadius, e.CornerRadius, e.EdgeInk.Paint(canvas, strip, paintstyle.Stroke))
+	for i := range e.gradient.Stops {
+		if i != e.selected {
+			e.drawHandle(canvas, i)
+		}
+	}
+	if e.selected >= 0 && e.selected < len(e.gradient.Stops) {
+		e.drawHandle(canvas, e.selected)
+	}
+}
+
+func (e *GradientEditor) drawHandle(canvas *Canvas, index int) {
+	r := e.handleRect(index)
+	edge := e.EdgeInk
+	thickness := float32(1)
+	if index == e.selected {
+		edge = e.SelectionInk
+		if e.Focused() {
+			thickness++
+		}
+	}
+	var bg Ink = e.gradient.Stops[index].Color.GetColor()
+	if !e.Enabled() {
+		bg = &ColorFilteredInk{OriginalInk: bg, ColorFilter: Grayscale30Filter()}
+	}
+	path := NewPath()
+	path.MoveTo(r.CenterX(), r.Y)
+	path.LineTo(r.Right(), r.Y+r.Height/2)
+	path.LineTo(r.Right(), r.Bottom())
+	path.LineTo(r.X, r.Bottom())
+	path.LineTo(r.X, r.Y+r.Height/2)
+	path.Close()
+	canvas.DrawPath(path, bg.Paint(canvas, r, paintstyle.Fill))
+	paint := edge.Paint(canvas, r, paintstyle.Stroke)
+	paint.SetStrokeWidth(thickness)
+	canvas.DrawPath(path, paint)
+}
+
+// DefaultMouseDown provides the default mouse down handling.
+func (e *GradientEditor) DefaultMouseDown(where Point, button, clickCount int, _ Modifiers) bool {
+	if button != ButtonLeft || !e.Enabled() {
+		return false
+	}
+	e.RequestFocus()
+	if index := e.stopAt(where); index != -1 {
+		e.SetSelectedStop(index)
+		if clickCount == 2 && e.EditStopColorCallback != nil {
+			toolbox.Call(func() { e.EditStopColorCallback(index) })
+			return true
+		}
+		e.dragging = true
+		return true
+	}
+	if index := e.AddStop(e.locationForPosition(where.X)); index != -1 {
+		e.dragging = true
+	}
+	return true
+}
+
+// DefaultMouseDrag provides the default mouse drag handling.
+func (e *GradientEditor) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
+	if e.dragging && e.selected >= 0 && e.selected < len(e.gradient.Stops) {
+		if location := e.locationForPosition(where.X); location != e.gradient.Stops[e.selected].Location {
+			e.SetStopLocation(e.selected, location)
+		}
+	}
+	return true
+}
+
+// DefaultMouseUp provides the default mouse up handling.
+func (e *GradientEditor) DefaultMouseUp(_ Point, _ int, _ Modifiers) bool {
+	e.dragging = false
+	return true
+}
+
+// DefaultKeyDown provides the default key down handling.
+func (e *GradientEditor) DefaultKeyDown(keyCode KeyCode, mod Modifiers, _ bool) bool {
+	if e.selected < 0 || e.selected >= len(e.gradient.Stops) {
+		return false
+	}
+	amount := e.NudgeAmount
+	if mod.ShiftDown() {
+		amount *= 10
+	}
+	location := e.gradient.Stops[e.selected].Location
+	switch keyCode {
+	case KeyLeft, KeyDown:
+		e.SetStopLocation(e.selected, location-amount)
+	case KeyRight, KeyUp:
+		e.SetStopLocation(e.selected, location+amount)
+	case KeyHome:
+		e.SetStopLocation(e.selected, 0)
+	case KeyEnd:
+		e.SetStopLocation(e.selected, 1)
+	case KeyBackspace, KeyDelete:
+		e.RemoveStop(e.selected)
+	default:
+		if IsControlAction(keyCode, mod) && e.EditStopColorCallback != nil {
+			index := e.selected
+			toolbox.Call(func() { e.EditStopColorCallback(index) })
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// DefaultUpdateCursor provides the default cursor.
+func (e *GradientEditor) DefaultUpdateCursor(where Point) *Cursor {
+	if e.Enabled() && e.stopAt(where) != -1 {
+		return PointingCursor()
+	}
+	return ArrowCursor()
+}
+
+// DefaultEditStopColor provides the default handling for editing a stop's color, which shows the ink well dialog
+// limited to colors.
+func (e *GradientEditor) DefaultEditStopColor(index int) {
+	if index < 0 || index >= len(e.gradient.Stops) {
+		return
+	}
+	w := NewWell()
+	w.Mask = ColorWellMask
+	w.SetInk(e.gradient.Stops[index].Color.GetColor())
+	showWellDialog(w)
+	if c, ok := w.Ink().(Color); ok && c != e.gradient.Stops[index].Color.GetColor() {
+		e.SetStopColor(index, c)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
 )
 
 // DrawRectBase fills and strokes a rectangle.
@@ -24,6 +25,9 @@ func DrawRectBase(canvas *Canvas, rect Rect, fillInk, strokeInk Ink) {
 
 // DrawRoundedRectBase fills and strokes a rounded rectangle.
 func DrawRoundedRectBase(canvas *Canvas, rect Rect, cornerRadius, thickness float32, fillInk, strokeInk Ink) {
+	if HighContrast {
+		thickness++
+	}
 	canvas.DrawRoundedRect(rect, cornerRadius, cornerRadius, fillInk.Paint(canvas, rect, paintstyle.Fill))
 	rect = rect.Inset(NewUniformInsets(thickness / 2))
 	cornerRadius = max(cornerRadius-thickness/2, 0)
@@ -34,6 +38,9 @@ func DrawRoundedRectBase(canvas *Canvas, rect Rect, cornerRadius, thickness floa
 
 // DrawEllipseBase fills and strokes an ellipse.
 func DrawEllipseBase(canvas *Canvas, rect Rect, thickness float32, fillInk, strokeInk Ink) {
+	if HighContrast {
+		thickness++
+	}
 	canvas.DrawOval(rect, fillInk.Paint(canvas, rect, paintstyle.Fill))
 	rect = rect.Inset(NewUniformInsets(thickness / 2))
 	p := strokeInk.Paint(canvas, rect, paintstyle.Stroke)
@@ -41,6 +48,28 @@ func DrawEllipseBase(canvas *Canvas, rect Rect, thickness float32, fillInk, stro
 	canvas.DrawOval(rect, p)
 }
 
+// DrawCheckerboard fills the rectangle with a checkerboard pattern of square cells, alternating between colorA and
+// colorB, starting with colorA in the top-left corner. This is typically drawn behind colors or images that may have
+// transparency, so that the transparency is visible.
+func DrawCheckerboard(canvas *Canvas, rect Rect, cellSize float32, colorA, colorB Ink) {
+	if rect.Empty() || cellSize <= 0 {
+		return
+	}
+	canvas.Save()
+	defer canvas.Restore()
+	canvas.ClipRect(rect, pathop.Intersect, false)
+	canvas.DrawRect(rect, colorA.Paint(canvas, rect, paintstyle.Fill))
+	path := NewPath()
+	row := 0
+	for y := rect.Y; y < rect.Bottom(); y += cellSize {
+		for x := rect.X + float32(1-row%2)*cellSize; x < rect.Right(); x += cellSize * 2 {
+			path.Rect(NewRect(x, y, cellSize, cellSize))
+		}
+		row++
+	}
+	canvas.DrawPath(path, colorB.Paint(canvas, rect, paintstyle.Fill))
+}
+
 // SanitizeExtensionList ensures the extension list is consistent:
 //
 //   - removal of leading and trailing white space
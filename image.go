@@ -24,6 +24,8 @@ import (
 	"github.com/richardwilkes/toolbox/softref"
 	"github.com/richardwilkes/toolbox/xio"
 	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison/enums/filtermode"
+	"github.com/richardwilkes/unison/enums/mipmapmode"
 	"github.com/richardwilkes/unison/internal/skia"
 )
 
@@ -51,7 +53,8 @@ func NewImageFromFilePathOrURLWithContext(ctx context.Context, filePathOrURL str
 	return NewImageFromBytes(data, scale)
 }
 
-// NewImageFromBytes creates a new image from raw bytes.
+// NewImageFromBytes creates a new image from raw bytes. Any EXIF orientation recorded in JPEG data is applied, so that
+// the resulting image is upright.
 func NewImageFromBytes(buffer []byte, scale float32) (*Image, error) {
 	if scale <= 0 {
 		return nil, errs.New("invalid scale")
@@ -59,6 +62,12 @@ func NewImageFromBytes(buffer []byte, scale float32) (*Image, error) {
 	if len(buffer) < 1 {
 		return nil, errs.New("no data in input buffer")
 	}
+	if orientation := jpegOrientation(buffer); orientation > 1 {
+		// Should Go's decoder reject the data, fall through to skia's, which also honors the orientation
+		if nrgba, err := decodeOrientedJPEG(buffer, orientation); err == nil {
+			return NewImageFromPixels(nrgba.Rect.Dx(), nrgba.Rect.Dy(), nrgba.Pix, scale)
+		}
+	}
 	data := skia.DataNewWithCopy(buffer)
 	defer skia.DataUnref(data)
 	img := skia.ImageNewFromEncoded(data)
@@ -99,46 +108,19 @@ func NewImageFromPixels(width, height int, pixels []byte, scale float32) (*Image
 	return newImage(img, scale, hash)
 }
 
-// NewImageFromDrawing creates a new image by drawing into it. This is currently fairly inefficient, so take care to use
-// it sparingly.
+// NewImageFromDrawing creates a new image by drawing into it. The drawing is rasterized on the CPU. This is currently
+// fairly inefficient, so take care to use it sparingly.
 func NewImageFromDrawing(width, height, ppi int, draw func(*Canvas)) (*Image, error) {
-	scale := float32(ppi) / 72
-	s := &surface{
-		context: skia.ContextMakeGL(defaultSkiaGL()),
-		surface: skia.SurfaceMakeRasterN32PreMul(&skia.ImageInfo{
-			Colorspace: skiaColorspace,
-			Width:      int32(xmath.Ceil(float32(width) * scale)),
-			Height:     int32(xmath.Ceil(float32(height) * scale)),
-			ColorType:  skia.ColorTypeRGBA8888,
-			AlphaType:  skia.AlphaTypeUnPreMul,
-		}, defaultSurfaceProps()),
-	}
-	c := &Canvas{
-		canvas:  skia.SurfaceGetCanvas(s.surface),
-		surface: s,
+	s, err := NewSoftwareSurface(Size{Width: float32(width), Height: float32(height)}, float32(ppi)/72)
+	if err != nil {
+		return nil, err
 	}
-	c.RestoreToCount(1)
-	c.SetMatrix(NewScaleMatrix(scale, scale))
+	defer s.Dispose()
+	c := s.Canvas()
 	c.Save()
 	toolbox.Call(func() { draw(c) })
 	c.Restore()
-	c.Flush()
-	defer s.dispose()
-	img := skia.SurfaceMakeImageSnapshot(s.surface)
-	width = skia.ImageGetWidth(img)
-	height = skia.ImageGetHeight(img)
-	pixels := make([]byte, width*height*4)
-	if !skia.ImageReadPixels(img, &skia.ImageInfo{
-		Colorspace: skiaColorspace,
-		Width:      int32(width),
-		Height:     int32(height),
-		ColorType:  skia.ColorTypeRGBA8888,
-		AlphaType:  skia.AlphaTypeUnPreMul,
-	}, pixels, width*4, 0, 0, skia.ImageCachingHintDisallow) {
-		return nil, errs.New("unable to read raw pixels from image")
-	}
-	skia.ImageUnref(img)
-	return NewImageFromPixels(width, height, pixels, 1)
+	return s.snapshotImage(1)
 }
 
 func newImage(img skia.Image, scale float32, hash uint64) (*Image, error) {
@@ -182,6 +164,33 @@ func (img *Image) DrawInRect(canvas *Canvas, rect Rect, sampling *SamplingOption
 	canvas.DrawImageInRect(img, rect, sampling, paint)
 }
 
+// Thumbnail returns a copy of this image that has been scaled down, preserving its aspect ratio, so that neither its
+// width nor its height exceeds maxDimension pixels. If the image is already small enough, it is returned as-is. If
+// sampling is nil, linear filtering with mipmaps will be used, which avoids the aliasing that a direct downscale would
+// produce. The resulting image has a scale of 1.
+func (img *Image) Thumbnail(maxDimension int, sampling *SamplingOptions) (*Image, error) {
+	if maxDimension < 1 {
+		return nil, errs.New("invalid maximum dimension")
+	}
+	size := img.Size()
+	largest := max(size.Width, size.Height)
+	if largest <= float32(maxDimension) {
+		return img, nil
+	}
+	if sampling == nil {
+		sampling = &SamplingOptions{
+			FilterMode: filtermode.Linear,
+			MipMapMode: mipmapmode.Linear,
+		}
+	}
+	ratio := float32(maxDimension) / largest
+	width := max(int(xmath.Round(size.Width*ratio)), 1)
+	height := max(int(xmath.Round(size.Height*ratio)), 1)
+	return NewImageFromDrawing(width, height, 72, func(canvas *Canvas) {
+		canvas.DrawImageInRect(img, Rect{Size: Size{Width: float32(width), Height: float32(height)}}, sampling, nil)
+	})
+}
+
 // Scale returns the internal scaling factor for this image.
 func (img *Image) Scale() float32 {
 	return img.ref().scale
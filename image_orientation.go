@@ -0,0 +1,151 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+const exifOrientationTag = 0x0112
+
+// jpegOrientation returns the EXIF orientation (1-8) recorded in the JPEG data, or 0 if the data isn't a JPEG or has no
+// orientation recorded.
+func jpegOrientation(buffer []byte) int {
+	if len(buffer) < 4 || buffer[0] != 0xFF || buffer[1] != 0xD8 {
+		return 0
+	}
+	i := 2
+	for i+4 <= len(buffer) {
+		if buffer[i] != 0xFF {
+			return 0
+		}
+		marker := buffer[i+1]
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // Start of scan or end of image; no more metadata follows
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(buffer[i+2:]))
+		if length < 2 || i+2+length > len(buffer) {
+			return 0
+		}
+		if marker == 0xE1 {
+			if orientation := exifOrientation(buffer[i+4 : i+2+length]); orientation != 0 {
+				return orientation
+			}
+		}
+		i += 2 + length
+	}
+	return 0
+}
+
+func exifOrientation(segment []byte) int {
+	if len(segment) < 14 || !bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+		return 0
+	}
+	tiff := segment[6:]
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for entry := offset + 2; count > 0 && entry+12 <= len(tiff); entry += 12 {
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 0
+		}
+		count--
+	}
+	return 0
+}
+
+// decodeOrientedJPEG decodes the JPEG data and applies the EXIF orientation to it, returning upright, non-premultiplied
+// RGBA pixels.
+func decodeOrientedJPEG(buffer []byte, orientation int) (*image.NRGBA, error) {
+	src, err := jpeg.Decode(bytes.NewReader(buffer))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	bounds := src.Bounds()
+	var pixel func(x, y int) (r, g, b uint8)
+	switch img := src.(type) {
+	case *image.YCbCr:
+		pixel = func(x, y int) (r, g, b uint8) {
+			c := img.COffset(x, y)
+			return color.YCbCrToRGB(img.Y[img.YOffset(x, y)], img.Cb[c], img.Cr[c])
+		}
+	case *image.Gray:
+		pixel = func(x, y int) (r, g, b uint8) {
+			v := img.Pix[img.PixOffset(x, y)]
+			return v, v, v
+		}
+	case *image.CMYK:
+		pixel = func(x, y int) (r, g, b uint8) {
+			i := img.PixOffset(x, y)
+			return color.CMYKToRGB(img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3])
+		}
+	default:
+		return nil, errs.Newf("unsupported JPEG image type: %T", src)
+	}
+	w := bounds.Dx()
+	h := bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	i := 0
+	for y := range dh {
+		for x := range dw {
+			var sx, sy int
+			switch orientation {
+			case 2: // Mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // Rotated 180 degrees
+				sx, sy = w-1-x, h-1-y
+			case 4: // Mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // Mirrored horizontally, then rotated 270 degrees clockwise
+				sx, sy = y, x
+			case 6: // Rotated 90 degrees clockwise
+				sx, sy = y, h-1-x
+			case 7: // Mirrored horizontally, then rotated 90 degrees clockwise
+				sx, sy = w-1-y, h-1-x
+			case 8: // Rotated 270 degrees clockwise
+				sx, sy = w-1-y, x
+			default:
+				sx, sy = x, y
+			}
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2] = pixel(bounds.Min.X+sx, bounds.Min.Y+sy)
+			dst.Pix[i+3] = 0xFF
+			i += 4
+		}
+	}
+	return dst, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestImageEXIFOrientation(t *testing.T) {
+	// A 32x16 image, red on the left half and blue on the right half, stored with an EXIF orientation of 6 (rotate 90
+	// degrees clockwise to display). Upright, it should be 16x32, with red on top and blue on the bottom.
+	src := image.NewNRGBA(image.Rect(0, 0, 32, 16))
+	for y := range 16 {
+		for x := range 32 {
+			if x < 16 {
+				src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				src.SetNRGBA(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buffer bytes.Buffer
+	check.NoError(t, jpeg.Encode(&buffer, src, &jpeg.Options{Quality: 100}))
+	img, err := unison.NewImageFromBytes(withEXIFOrientation(buffer.Bytes(), 6), 1)
+	check.NoError(t, err)
+	check.Equal(t, unison.NewSize(16, 32), img.Size())
+	nrgba, err := img.ToNRGBA()
+	check.NoError(t, err)
+	top := nrgba.NRGBAAt(8, 4)
+	bottom := nrgba.NRGBAAt(8, 28)
+	check.True(t, top.R > 200 && top.B < 50)
+	check.True(t, bottom.B > 200 && bottom.R < 50)
+}
+
+// withEXIFOrientation inserts a minimal big-endian EXIF segment holding the orientation just after the JPEG SOI marker.
+func withEXIFOrientation(data []byte, orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0x0112))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(3))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.BigEndian, orientation)
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0))
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	var out bytes.Buffer
+	out.Write(data[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	_ = binary.Write(&out, binary.BigEndian, uint16(len(payload)+2))
+	out.Write(payload)
+	out.Write(data[2:])
+	return out.Bytes()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import "github.com/richardwilkes/unison/enums/paintstyle"
+
+// DefaultDropIndicatorTheme holds the IndicatorTheme used by DrawDropIndicator.
+var DefaultDropIndicatorTheme = IndicatorTheme{
+	StrokeWidth: 2,
+	MiterLimit:  4,
+	FillAlpha:   0.3,
+}
+
+// IndicatorTheme holds theming data for indicators, such as those drawn by DrawFocusRect and DrawDropIndicator.
+type IndicatorTheme struct {
+	// Dashes, if not empty, holds the alternating lengths of the dashes and gaps used to stroke the indicator.
+	Dashes       []float32
+	StrokeWidth  float32
+	CornerRadius float32
+	MiterLimit   float32
+	// FillAlpha is the opacity, from 0 to 1, of the ink used to fill the interior of the indicator. Zero leaves the
+	// interior unfilled.
+	FillAlpha float32
+}
+
+// StrokeRect returns the rectangle that the center of the indicator's stroke follows when drawn within the given
+// rectangle, along with the corner radius to use for it. The stroke is kept entirely within the given rectangle.
+func (t *IndicatorTheme) StrokeRect(rect Rect) (strokeRect Rect, cornerRadius float32) {
+	width := t.strokeWidth()
+	return rect.Inset(NewUniformInsets(width / 2)), max(t.CornerRadius-width/2, 0)
+}
+
+func (t *IndicatorTheme) strokeWidth() float32 {
+	width := t.StrokeWidth
+	if HighContrast {
+		width++
+	}
+	return width
+}
+
+// Draw the indicator within the rectangle.
+func (t *IndicatorTheme) Draw(canvas *Canvas, rect Rect, ink Ink) {
+	if t.FillAlpha > 0 {
+		paint := ink.Paint(canvas, rect, paintstyle.Fill)
+		paint.SetColorFilter(NewAlphaFilter(t.FillAlpha))
+		canvas.DrawRoundedRect(rect, t.CornerRadius, t.CornerRadius, paint)
+	}
+	r, radius := t.StrokeRect(rect)
+	paint := ink.Paint(canvas, r, paintstyle.Stroke)
+	paint.SetStrokeWidth(t.strokeWidth())
+	if t.MiterLimit > 0 {
+		paint.SetStrokeMiter(t.MiterLimit)
+	}
+	if len(t.Dashes) != 0 {
+		paint.SetPathEffect(NewDashPathEffect(t.Dashes, 0))
+	}
+	canvas.DrawRoundedRect(r, radius, radius, paint)
+}
+
+// DrawFocusRect draws a focus indicator within the rectangle, as described by DefaultFocusRingTheme. If ink is nil, the
+// theme's Ink is used.
+func DrawFocusRect(canvas *Canvas, rect Rect, ink Ink) {
+	DefaultFocusRingTheme.DrawFocusRect(canvas, rect, ink)
+}
+
+// DrawDropIndicator draws an indicator within the rectangle showing where a drop will occur, as described by
+// DefaultDropIndicatorTheme.
+func DrawDropIndicator(canvas *Canvas, rect Rect, ink Ink) {
+	DefaultDropIndicatorTheme.Draw(canvas, rect, ink)
+}
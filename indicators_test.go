@@ -0,0 +1,36 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestIndicatorStrokeRect(t *testing.T) {
+	theme := unison.IndicatorTheme{StrokeWidth: 2, CornerRadius: 4}
+	r, radius := theme.StrokeRect(unison.NewRect(10, 20, 100, 50))
+	check.Equal(t, unison.NewRect(11, 21, 98, 48), r)
+	check.Equal(t, float32(3), radius)
+
+	theme = unison.IndicatorTheme{StrokeWidth: 3, CornerRadius: 1}
+	r, radius = theme.StrokeRect(unison.NewRect(0, 0, 10, 10))
+	check.Equal(t, unison.NewRect(1.5, 1.5, 7, 7), r)
+	check.Equal(t, float32(0), radius)
+
+	saved := unison.HighContrast
+	unison.HighContrast = true
+	defer func() { unison.HighContrast = saved }()
+	theme = unison.IndicatorTheme{StrokeWidth: 2}
+	r, _ = theme.StrokeRect(unison.NewRect(0, 0, 10, 10))
+	check.Equal(t, unison.NewRect(1.5, 1.5, 7, 7), r)
+}
@@ -14,6 +14,7 @@ import "github.com/richardwilkes/unison/enums/paintstyle"
 var (
 	_ Ink = &IndirectInk{}
 	_ Ink = &ColorFilteredInk{}
+	_ Ink = &DynamicInk{}
 )
 
 // Ink holds a color, pattern, or gradient to draw with.
@@ -45,3 +46,18 @@ func (c *ColorFilteredInk) Paint(canvas *Canvas, rect Rect, style paintstyle.Enu
 	}
 	return paint
 }
+
+// DynamicInk holds an ink for light mode and an ink for dark mode, choosing between them based on the current theme
+// mode each time it is used.
+type DynamicInk struct {
+	Light Ink
+	Dark  Ink
+}
+
+// Paint implements Ink.
+func (d *DynamicInk) Paint(canvas *Canvas, rect Rect, style paintstyle.Enum) *Paint {
+	if IsDarkModeEnabled() {
+		return d.Dark.Paint(canvas, rect, style)
+	}
+	return d.Light.Paint(canvas, rect, style)
+}
@@ -30,6 +30,18 @@ double doubleClickInterval() {
 	return [NSEvent doubleClickInterval];
 }
 
+long pasteboardChangeCount() {
+	return [[NSPasteboard generalPasteboard] changeCount];
+}
+
+bool accessibilityShouldReduceMotion() {
+	return [[NSWorkspace sharedWorkspace] accessibilityDisplayShouldReduceMotion];
+}
+
+bool accessibilityShouldIncreaseContrast() {
+	return [[NSWorkspace sharedWorkspace] accessibilityDisplayShouldIncreaseContrast];
+}
+
 NSEventModifierFlags eventModifierFlags() {
 	return NSEvent.modifierFlags;
 }
@@ -440,6 +452,18 @@ func DoubleClickInterval() time.Duration {
 	return time.Duration(C.doubleClickInterval()*1000) * time.Millisecond
 }
 
+func PasteboardChangeCount() int {
+	return int(C.pasteboardChangeCount())
+}
+
+func AccessibilityShouldReduceMotion() bool {
+	return bool(C.accessibilityShouldReduceMotion())
+}
+
+func AccessibilityShouldIncreaseContrast() bool {
+	return bool(C.accessibilityShouldIncreaseContrast())
+}
+
 func CurrentModifierFlags() EventModifierFlags {
 	return EventModifierFlags(C.eventModifierFlags())
 }
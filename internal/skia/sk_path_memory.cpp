@@ -0,0 +1,32 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+//go:build !windows
+
+#include "sk_capi.h"
+
+// The C API doesn't expose SkPath's binary serialization, so provide wrappers for it here. Only the two members that
+// are needed are declared, which is sufficient to call them, as they are non-virtual.
+class SkPath {
+public:
+	size_t writeToMemory(void* buffer) const;
+	size_t readFromMemory(const void* buffer, size_t length);
+};
+
+extern "C" {
+
+size_t unison_sk_path_write_to_memory(const sk_path_t* cpath, void* buffer) {
+	return reinterpret_cast<const SkPath*>(cpath)->writeToMemory(buffer);
+}
+
+size_t unison_sk_path_read_from_memory(sk_path_t* cpath, const void* buffer, size_t length) {
+	return reinterpret_cast<SkPath*>(cpath)->readFromMemory(buffer, length);
+}
+
+}
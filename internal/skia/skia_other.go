@@ -21,6 +21,10 @@ package skia
 #include <stdlib.h>
 #include <string.h>
 #include "sk_capi.h"
+
+// Implemented in sk_path_memory.cpp
+size_t unison_sk_path_write_to_memory(const sk_path_t* cpath, void* buffer);
+size_t unison_sk_path_read_from_memory(sk_path_t* cpath, const void* buffer, size_t length);
 */
 import "C"
 
@@ -914,6 +918,23 @@ func PathToSVGString(path Path, absolute bool) String {
 	return C.sk_path_to_svg_string(path, C.bool(absolute))
 }
 
+func PathSerialize(path Path) []byte {
+	buffer := make([]byte, C.unison_sk_path_write_to_memory(path, nil))
+	C.unison_sk_path_write_to_memory(path, unsafe.Pointer(&buffer[0]))
+	return buffer
+}
+
+func PathDeserialize(path Path, data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	// Skia requires the data to be 4-byte aligned, which a sub-slice may not be, so copy it into a fresh allocation
+	buffer := make([]byte, len(data))
+	copy(buffer, data)
+	return C.unison_sk_path_read_from_memory(path, unsafe.Pointer(&buffer[0]), C.size_t(len(buffer))) ==
+		C.size_t(len(buffer))
+}
+
 func PathGetFillType(path Path) FillType {
 	return FillType(C.sk_path_get_filltype(path))
 }
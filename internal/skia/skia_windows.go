@@ -225,6 +225,8 @@ var (
 	skPathNewProc                                  *syscall.Proc
 	skPathParseSVGStringProc                       *syscall.Proc
 	skPathToSVGStringProc                          *syscall.Proc
+	skPathWriteToMemoryProc                        *syscall.Proc
+	skPathReadFromMemoryProc                       *syscall.Proc
 	skPathGetFillTypeProc                          *syscall.Proc
 	skPathSetFillTypeProc                          *syscall.Proc
 	skPathArcToProc                                *syscall.Proc
@@ -529,6 +531,10 @@ func init() {
 	skPathNewProc = skia.MustFindProc("sk_path_new")
 	skPathParseSVGStringProc = skia.MustFindProc("sk_path_parse_svg_string")
 	skPathToSVGStringProc = skia.MustFindProc("sk_path_to_svg_string")
+	// SkPath's binary serialization isn't exposed by the C API. The other platforms provide wrappers for it via cgo
+	// (see sk_path_memory.cpp), which isn't used here, so the members exported by the bundled DLL are used directly.
+	skPathWriteToMemoryProc = skia.MustFindProc("?writeToMemory@SkPath@@QEBA_KPEAX@Z")
+	skPathReadFromMemoryProc = skia.MustFindProc("?readFromMemory@SkPath@@QEAA_KPEBX_K@Z")
 	skPathGetFillTypeProc = skia.MustFindProc("sk_path_get_filltype")
 	skPathSetFillTypeProc = skia.MustFindProc("sk_path_set_filltype")
 	skPathArcToProc = skia.MustFindProc("sk_path_arc_to")
@@ -1638,6 +1644,24 @@ func PathToSVGString(path Path, absolute bool) String {
 	return String(r1)
 }
 
+func PathSerialize(path Path) []byte {
+	r1, _, _ := skPathWriteToMemoryProc.Call(uintptr(path), 0)
+	buffer := make([]byte, r1)
+	skPathWriteToMemoryProc.Call(uintptr(path), uintptr(unsafe.Pointer(&buffer[0])))
+	return buffer
+}
+
+func PathDeserialize(path Path, data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	// Skia requires the data to be 4-byte aligned, which a sub-slice may not be, so copy it into a fresh allocation
+	buffer := make([]byte, len(data))
+	copy(buffer, data)
+	r1, _, _ := skPathReadFromMemoryProc.Call(uintptr(path), uintptr(unsafe.Pointer(&buffer[0])), uintptr(len(buffer)))
+	return r1 == uintptr(len(buffer))
+}
+
 func PathGetFillType(path Path) FillType {
 	r1, _, _ := skPathGetFillTypeProc.Call(uintptr(path))
 	return FillType(r1)
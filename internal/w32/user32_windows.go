@@ -12,6 +12,7 @@ package w32
 import (
 	"syscall"
 	"time"
+	"unsafe"
 )
 
 var (
@@ -27,6 +28,7 @@ var (
 	messageBeepProc                = user32.NewProc("MessageBeep")
 	openClipboardProc              = user32.NewProc("OpenClipboard")
 	setClipboardDataProc           = user32.NewProc("SetClipboardData")
+	systemParametersInfoProc       = user32.NewProc("SystemParametersInfoW")
 )
 
 // Clipboard format types https://docs.microsoft.com/en-us/windows/desktop/dataxchg/standard-clipboard-formats
@@ -42,6 +44,22 @@ const (
 // ColorHighlight https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getsyscolor
 const ColorHighlight = 13
 
+// SystemParametersInfo actions https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-systemparametersinfow
+const (
+	SPIGetHighContrast        = 0x0042
+	SPIGetClientAreaAnimation = 0x1042
+)
+
+// HCFHighContrastOn https://learn.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-highcontrastw
+const HCFHighContrastOn = 0x00000001
+
+// HighContrast https://learn.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-highcontrastw
+type HighContrast struct {
+	Size          uint32
+	Flags         uint32
+	DefaultScheme *uint16
+}
+
 // BeepType https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-messagebeep
 type BeepType uint
 
@@ -119,3 +137,19 @@ func SetClipboardData(format ClipboardFormat, handle syscall.Handle) syscall.Han
 	h, _, _ := setClipboardDataProc.Call(uintptr(format), uintptr(handle))
 	return syscall.Handle(h)
 }
+
+// ClientAreaAnimationEnabled returns the SPI_GETCLIENTAREAANIMATION setting.
+// https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-systemparametersinfow
+func ClientAreaAnimationEnabled() bool {
+	var enabled int32 = 1
+	systemParametersInfoProc.Call(SPIGetClientAreaAnimation, 0, uintptr(unsafe.Pointer(&enabled)), 0)
+	return enabled != 0
+}
+
+// HighContrastEnabled returns true if the SPI_GETHIGHCONTRAST setting has the HCF_HIGHCONTRASTON flag set.
+// https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-systemparametersinfow
+func HighContrastEnabled() bool {
+	hc := HighContrast{Size: uint32(unsafe.Sizeof(HighContrast{}))}
+	r, _, _ := systemParametersInfoProc.Call(SPIGetHighContrast, uintptr(hc.Size), uintptr(unsafe.Pointer(&hc)), 0)
+	return r != 0 && hc.Flags&HCFHighContrastOn != 0
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 	"github.com/richardwilkes/unison/enums/pathop"
+	"github.com/richardwilkes/unison/enums/rounding"
 	"github.com/richardwilkes/unison/enums/side"
 )
 
@@ -25,14 +26,18 @@ var DefaultLabelTheme = LabelTheme{
 		Font:            LabelFont,
 		OnBackgroundInk: ThemeOnSurface,
 	},
-	Gap:    3,
-	HAlign: align.Start,
-	VAlign: align.Middle,
-	Side:   side.Left,
+	SelectionInk:   ThemeFocus,
+	OnSelectionInk: ThemeOnFocus,
+	Gap:            3,
+	HAlign:         align.Start,
+	VAlign:         align.Middle,
+	Side:           side.Left,
 }
 
 // LabelTheme holds theming data for a Label.
 type LabelTheme struct {
+	SelectionInk   Ink
+	OnSelectionInk Ink
 	TextDecoration
 	Gap    float32
 	HAlign align.Enum
@@ -40,12 +45,17 @@ type LabelTheme struct {
 	Side   side.Enum
 }
 
-// Label represents non-interactive text and/or a Drawable.
+// Label represents non-interactive text and/or a Drawable. The text may optionally be made selectable, so that it can
+// be copied, but it is never editable.
 type Label struct {
 	Drawable Drawable
 	Text     *Text
 	LabelTheme
 	Panel
+	selectionStart  int
+	selectionEnd    int
+	selectionAnchor int
+	selectable      bool
 }
 
 // NewLabel creates a new, empty label.
@@ -66,6 +76,163 @@ func (l *Label) String() string {
 // .Text field.
 func (l *Label) SetTitle(text string) {
 	l.Text = NewText(text, &l.TextDecoration)
+	l.selectionStart = 0
+	l.selectionEnd = 0
+	l.selectionAnchor = 0
+}
+
+// Selectable returns true if the text of the label may be selected and copied by the user.
+func (l *Label) Selectable() bool {
+	return l.selectable
+}
+
+// SetSelectable sets whether the text of the label may be selected and copied by the user. Making a label selectable
+// also makes it focusable and installs the mouse, cursor, and Copy & Select All command handlers it needs.
+func (l *Label) SetSelectable(selectable bool) {
+	if l.selectable == selectable {
+		return
+	}
+	l.selectable = selectable
+	l.SetFocusable(selectable)
+	if selectable {
+		l.MouseDownCallback = l.DefaultMouseDown
+		l.MouseDragCallback = l.DefaultMouseDrag
+		l.UpdateCursorCallback = l.DefaultUpdateCursor
+		l.LostFocusCallback = l.MarkForRedraw
+		l.InstallCmdHandlers(CopyItemID, func(_ any) bool { return l.CanCopy() }, func(_ any) { l.Copy() })
+		l.InstallCmdHandlers(SelectAllItemID, func(_ any) bool { return l.CanSelectAll() }, func(_ any) { l.SelectAll() })
+	} else {
+		l.SetSelection(0, 0)
+	}
+	l.MarkForRedraw()
+}
+
+func (l *Label) runes() []rune {
+	if l.Text == nil {
+		return nil
+	}
+	return l.Text.Runes()
+}
+
+// Selection returns the start and end of the selected text, as rune indexes.
+func (l *Label) Selection() (start, end int) {
+	length := len(l.runes())
+	return min(l.selectionStart, length), min(l.selectionEnd, length)
+}
+
+// SetSelection sets the selected text, as rune indexes.
+func (l *Label) SetSelection(start, end int) {
+	l.setSelection(start, end, start)
+}
+
+func (l *Label) setSelection(start, end, anchor int) {
+	length := len(l.runes())
+	start = max(min(start, length), 0)
+	end = max(min(end, length), start)
+	if l.selectionStart != start || l.selectionEnd != end || l.selectionAnchor != anchor {
+		l.selectionStart = start
+		l.selectionEnd = end
+		l.selectionAnchor = max(min(anchor, length), 0)
+		l.MarkForRedraw()
+	}
+}
+
+// SelectedText returns the currently selected text.
+func (l *Label) SelectedText() string {
+	start, end := l.Selection()
+	return string(l.runes()[start:end])
+}
+
+// CanCopy returns true if the label is selectable and has a selection range.
+func (l *Label) CanCopy() bool {
+	start, end := l.Selection()
+	return l.selectable && start < end
+}
+
+// Copy the selected text to the clipboard.
+func (l *Label) Copy() {
+	if l.CanCopy() {
+		GlobalClipboard.SetText(l.SelectedText())
+	}
+}
+
+// CanSelectAll returns true if the label is selectable and its text isn't already entirely selected.
+func (l *Label) CanSelectAll() bool {
+	start, end := l.Selection()
+	return l.selectable && (start != 0 || end != len(l.runes()))
+}
+
+// SelectAll selects all of the text in the label.
+func (l *Label) SelectAll() {
+	l.SetSelection(0, len(l.runes()))
+}
+
+// textOrigin returns the location of the upper-left corner of the label's text.
+func (l *Label) textOrigin() Point {
+	_, _, txtPt := labelLayout(l.ContentRect(false), l.HAlign, l.VAlign, l.Font, l.Text, l.Drawable, l.Side, l.Gap)
+	return txtPt
+}
+
+func (l *Label) selectionIndexAt(where Point) int {
+	if l.Text.Empty() {
+		return 0
+	}
+	return l.Text.RuneIndexForPosition(where.X - l.textOrigin().X)
+}
+
+// DefaultMouseDown provides the default mouse down handling when the label is selectable.
+func (l *Label) DefaultMouseDown(where Point, button, clickCount int, mod Modifiers) bool {
+	if !l.selectable || button != ButtonLeft {
+		return false
+	}
+	l.RequestFocus()
+	if l.Text.Empty() {
+		return true
+	}
+	index := l.selectionIndexAt(where)
+	switch clickCount {
+	case 2:
+		runes := l.runes()
+		start := min(l.Text.RuneIndexForPositionWithRounding(where.X-l.textOrigin().X, rounding.Floor), len(runes))
+		end := start
+		breaker := DefaultWordBreaker
+		for start > 0 && breaker.IsWordPart(runes, start-1) && !breaker.IsWordBoundary(runes, start) {
+			start--
+		}
+		for end < len(runes) && breaker.IsWordPart(runes, end) && (end == start || !breaker.IsWordBoundary(runes, end)) {
+			end++
+		}
+		l.SetSelection(start, end)
+	case 3:
+		l.SelectAll()
+	default:
+		if mod.ShiftDown() {
+			anchor := l.selectionAnchor
+			l.setSelection(min(anchor, index), max(anchor, index), anchor)
+		} else {
+			l.SetSelection(index, index)
+		}
+	}
+	return true
+}
+
+// DefaultMouseDrag provides the default mouse drag handling when the label is selectable.
+func (l *Label) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
+	if !l.selectable {
+		return false
+	}
+	index := l.selectionIndexAt(where)
+	anchor := l.selectionAnchor
+	l.setSelection(min(anchor, index), max(anchor, index), anchor)
+	return true
+}
+
+// DefaultUpdateCursor provides the default cursor for selectable labels.
+func (l *Label) DefaultUpdateCursor(_ Point) *Cursor {
+	if l.selectable && !l.Text.Empty() {
+		return TextCursor()
+	}
+	return ArrowCursor()
 }
 
 // DefaultSizes provides the default sizing.
@@ -82,6 +249,23 @@ func (l *Label) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 func (l *Label) DefaultDraw(canvas *Canvas, _ Rect) {
 	DrawLabel(canvas, l.ContentRect(false), l.HAlign, l.VAlign, l.Font, l.Text, l.OnBackgroundInk, l.BackgroundInk,
 		l.Drawable, l.Side, l.Gap, !l.Enabled())
+	if start, end := l.Selection(); l.selectable && start < end && l.Focused() {
+		// Redraw the selected portion of the text over a selection background, clipped to just the selection
+		txtPt := l.textOrigin()
+		left := txtPt.X + l.Text.PositionForRuneIndex(start)
+		selRect := NewRect(left, txtPt.Y, txtPt.X+l.Text.PositionForRuneIndex(end)-left, l.Text.Height())
+		canvas.Save()
+		canvas.ClipRect(l.ContentRect(false), pathop.Intersect, false)
+		canvas.ClipRect(selRect, pathop.Intersect, false)
+		canvas.DrawRect(selRect, l.SelectionInk.Paint(canvas, selRect, paintstyle.Fill))
+		saved := l.Text.AdjustDecorations(func(d *TextDecoration) {
+			d.BackgroundInk = nil
+			d.OnBackgroundInk = l.OnSelectionInk
+		})
+		l.Text.Draw(canvas, txtPt.X, txtPt.Y+l.Text.Baseline())
+		l.Text.RestoreDecorations(saved)
+		canvas.Restore()
+	}
 }
 
 // LabelContentSizes returns the preferred size of a label, as well as the preferred size of the text within the label.
@@ -124,6 +308,41 @@ func DrawLabel(canvas *Canvas, rect Rect, hAlign, vAlign align.Enum, font Font,
 		return
 	}
 
+	rect, imgPt, txtPt := labelLayout(rect, hAlign, vAlign, font, text, drawable, drawableSide, imgGap)
+	canvas.Save()
+	canvas.ClipRect(rect, pathop.Intersect, false)
+	if drawable != nil {
+		rect.Point = imgPt
+		rect.Size = drawable.LogicalSize()
+		fg := onBackgroundInk
+		if applyDisabledFilter {
+			fg = &ColorFilteredInk{
+				OriginalInk: fg,
+				ColorFilter: Grayscale30Filter(),
+			}
+		}
+		drawable.DrawInRect(canvas, rect, nil, fg.Paint(canvas, rect, paintstyle.Fill))
+	}
+	if !empty {
+		if applyDisabledFilter {
+			defer text.RestoreDecorations(text.AdjustDecorations(func(decoration *TextDecoration) {
+				decoration.OnBackgroundInk = &ColorFilteredInk{
+					OriginalInk: decoration.OnBackgroundInk,
+					ColorFilter: Grayscale30Filter(),
+				}
+			}))
+		}
+		text.Draw(canvas, txtPt.X, txtPt.Y+text.Baseline())
+	}
+	canvas.Restore()
+}
+
+// labelLayout determines the area occupied by the content of a label within rect, along with the origins of the
+// drawable and text within that area.
+func labelLayout(rect Rect, hAlign, vAlign align.Enum, font Font, text *Text, drawable Drawable, drawableSide side.Enum,
+	imgGap float32) (area Rect, imgPt, txtPt Point) {
+	empty := text.Empty()
+
 	// Determine overall size of content
 	size, txtSize := LabelContentSizes(text, drawable, font, drawableSide, imgGap)
 
@@ -184,32 +403,5 @@ func DrawLabel(canvas *Canvas, rect Rect, hAlign, vAlign align.Enum, font Font,
 			}
 		}
 	}
-
-	canvas.Save()
-	canvas.ClipRect(rect, pathop.Intersect, false)
-	if drawable != nil {
-		rect.X = imgX
-		rect.Y = imgY
-		rect.Size = drawable.LogicalSize()
-		fg := onBackgroundInk
-		if applyDisabledFilter {
-			fg = &ColorFilteredInk{
-				OriginalInk: fg,
-				ColorFilter: Grayscale30Filter(),
-			}
-		}
-		drawable.DrawInRect(canvas, rect, nil, fg.Paint(canvas, rect, paintstyle.Fill))
-	}
-	if !empty {
-		if applyDisabledFilter {
-			defer text.RestoreDecorations(text.AdjustDecorations(func(decoration *TextDecoration) {
-				decoration.OnBackgroundInk = &ColorFilteredInk{
-					OriginalInk: decoration.OnBackgroundInk,
-					ColorFilter: Grayscale30Filter(),
-				}
-			}))
-		}
-		text.Draw(canvas, txtX, txtY+text.Baseline())
-	}
-	canvas.Restore()
+	return rect, Point{X: imgX, Y: imgY}, Point{X: txtX, Y: txtY}
 }
@@ -24,10 +24,12 @@ var DefaultLinkTheme = LinkTheme{
 			OnBackgroundInk: ThemeFocus,
 			Underline:       true,
 		},
-		Gap:    3,
-		HAlign: align.Start,
-		VAlign: align.Middle,
-		Side:   side.Left,
+		SelectionInk:   ThemeFocus,
+		OnSelectionInk: ThemeOnFocus,
+		Gap:            3,
+		HAlign:         align.Start,
+		VAlign:         align.Middle,
+		Side:           side.Left,
 	},
 	PressedInk:   ThemeFocus,
 	OnPressedInk: ThemeOnFocus,
@@ -15,7 +15,6 @@ import (
 
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/xmath"
-	"github.com/richardwilkes/unison/enums/paintstyle"
 )
 
 // DefaultListTheme holds the default ListTheme values for Lists. Modifying this data will not alter existing Lists,
@@ -49,10 +48,13 @@ type ListTheme struct {
 type List[T any] struct {
 	DoubleClickCallback  func()
 	NewSelectionCallback func()
-	Factory              CellFactory
-	Selection            *xmath.BitSet
-	savedSelection       *xmath.BitSet
-	rows                 []T
+	// RowHeightCallback, if set, returns the height of the row at the given index. This avoids having to create a cell
+	// just to determine its height when rows vary in height.
+	RowHeightCallback func(row int) float32
+	Factory           CellFactory
+	Selection         *xmath.BitSet
+	savedSelection    *xmath.BitSet
+	rows              []T
 	ListTheme
 	Panel
 	anchor            int
@@ -180,10 +182,7 @@ func (l *List[T]) RemoveRange(from, to int) {
 // DefaultSizes provides the default sizing.
 func (l *List[T]) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 	maxSize = MaxSize(maxSize)
-	height := xmath.Ceil(l.Factory.CellHeight())
-	if height < 1 {
-		height = 0
-	}
+	height := l.fixedRowHeight()
 	size := Size{Width: hint.Width, Height: height}
 	for row := range l.rows {
 		cell := l.cell(row)
@@ -197,8 +196,14 @@ func (l *List[T]) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
 			maxSize.Width = cMax.Width
 		}
 		if height < 1 {
-			prefSize.Height += cPref.Height
-			maxSize.Height += cMax.Height
+			if l.RowHeightCallback != nil {
+				rowHeight := l.rowHeight(row)
+				prefSize.Height += rowHeight
+				maxSize.Height += rowHeight
+			} else {
+				prefSize.Height += cPref.Height
+				maxSize.Height += cMax.Height
+			}
 		}
 	}
 	if height >= 1 {
@@ -256,19 +261,43 @@ func (l *List[T]) cell(row int) *Panel {
 	return l.Factory.CreateCell(l, l.rows[row], row, fg, bg, selected, focused).AsPanel()
 }
 
+// fixedRowHeight returns the height shared by all rows, or 0 if rows may vary in height.
+func (l *List[T]) fixedRowHeight() float32 {
+	if l.RowHeightCallback != nil {
+		return 0
+	}
+	if height := xmath.Ceil(l.Factory.CellHeight()); height >= 1 {
+		return height
+	}
+	return 0
+}
+
+// rowHeight returns the height of the specified row.
+func (l *List[T]) rowHeight(row int) float32 {
+	if l.RowHeightCallback != nil {
+		return xmath.Ceil(l.RowHeightCallback(row))
+	}
+	if height := l.fixedRowHeight(); height >= 1 {
+		return height
+	}
+	_, pref, _ := l.cell(row).Sizes(Size{})
+	return pref.Ceil().Height
+}
+
 // RowRect returns the rectangle for the specified row.
 func (l *List[T]) RowRect(row int) Rect {
 	if row < 0 || row >= len(l.rows) {
 		return Rect{}
 	}
 	rect := l.ContentRect(false)
-	cellHeight := xmath.Ceil(l.Factory.CellHeight())
-	if cellHeight < 1 {
-		_, pref, _ := l.cell(row).Sizes(Size{})
-		cellHeight = pref.Ceil().Height
+	if height := l.fixedRowHeight(); height >= 1 {
+		rect.Y += height * float32(row)
+	} else {
+		for i := range row {
+			rect.Y += l.rowHeight(i)
+		}
 	}
-	rect.Y += cellHeight * float32(row)
-	rect.Height = cellHeight
+	rect.Height = l.rowHeight(row)
 	return rect
 }
 
@@ -276,10 +305,10 @@ func (l *List[T]) RowRect(row int) Rect {
 func (l *List[T]) DefaultDraw(canvas *Canvas, dirty Rect) {
 	rect := l.ContentRect(false)
 	intersect := rect.Intersect(dirty)
-	canvas.DrawRect(intersect, l.BackgroundInk.Paint(canvas, intersect, paintstyle.Fill))
+	canvas.FillRectWithInk(intersect, l.BackgroundInk)
 	row, y := l.rowAt(dirty.Y)
 	if row >= 0 {
-		cellHeight := xmath.Ceil(l.Factory.CellHeight())
+		cellHeight := l.fixedRowHeight()
 		count := len(l.rows)
 		yMax := dirty.Y + dirty.Height
 		for row < count && y < yMax {
@@ -287,13 +316,17 @@ func (l *List[T]) DefaultDraw(canvas *Canvas, dirty Rect) {
 			cell := l.Factory.CreateCell(l, l.rows[row], row, fg, bg, selected, focused).AsPanel()
 			cellRect := Rect{Point: Point{X: rect.X, Y: y}, Size: Size{Width: rect.Width, Height: cellHeight}}
 			if cellHeight < 1 {
-				_, pref, _ := cell.Sizes(Size{})
-				cellRect.Height = pref.Ceil().Height
+				if l.RowHeightCallback != nil {
+					cellRect.Height = l.rowHeight(row)
+				} else {
+					_, pref, _ := cell.Sizes(Size{})
+					cellRect.Height = pref.Ceil().Height
+				}
 			}
 			cell.SetFrameRect(cellRect)
 			y += cellRect.Height
 			r := Rect{Point: Point{X: rect.X, Y: cellRect.Y}, Size: Size{Width: rect.Width, Height: cellRect.Height}}
-			canvas.DrawRect(r, bg.Paint(canvas, r, paintstyle.Fill))
+			canvas.FillRectWithInk(r, bg)
 			canvas.Save()
 			tl := cellRect.Point
 			dirty.Point = dirty.Point.Sub(tl)
@@ -541,15 +574,14 @@ func (l *List[T]) SetAllowMultipleSelection(allow bool) *List[T] {
 func (l *List[T]) rowAt(y float32) (row int, top float32) {
 	count := len(l.rows)
 	top = l.ContentRect(false).Y
-	cellHeight := xmath.Ceil(l.Factory.CellHeight())
+	cellHeight := l.fixedRowHeight()
 	if cellHeight < 1 {
 		for row < count {
-			_, pref, _ := l.cell(row).Sizes(Size{})
-			pref = pref.Ceil()
-			if top+pref.Height >= y {
+			height := l.rowHeight(row)
+			if top+height >= y {
 				break
 			}
-			top += pref.Height
+			top += height
 			row++
 		}
 	} else {
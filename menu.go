@@ -76,11 +76,15 @@ type menuPanel struct {
 }
 
 type menu struct {
-	factory    *inWindowMenuFactory
-	titleItem  *menuItem
-	popupPanel *menuPanel
-	updater    func(Menu)
-	items      []*menuItem
+	factory         *inWindowMenuFactory
+	titleItem       *menuItem
+	popupPanel      *menuPanel
+	updater         func(Menu)
+	closedCallback  func()
+	items           []*menuItem
+	columns         int
+	rows            int
+	maxColumnHeight float32
 }
 
 func (m *menu) Factory() MenuFactory {
@@ -148,6 +152,15 @@ func (m *menu) InsertSeparator(atIndex int, onlyIfNeeded bool) {
 	})
 }
 
+func (m *menu) insertCaptionedSeparator(atIndex int, caption string) {
+	m.insertItem(atIndex, &menuItem{
+		factory:     m.factory,
+		menu:        m,
+		title:       caption,
+		isSeparator: true,
+	})
+}
+
 func (m *menu) InsertItem(atIndex int, mi MenuItem) {
 	if mi != nil {
 		m.insertItem(atIndex, mi.(*menuItem))
@@ -203,7 +216,7 @@ func (m *menu) Popup(where Rect, itemIndex int) {
 		m.createPopup()
 		if itemIndex >= 0 && itemIndex < len(m.items) {
 			m.popupPanel.ValidateLayout()
-			where.Y -= m.popupPanel.Children()[0].Self.(*ScrollPanel).Content().AsPanel().Children()[itemIndex].FrameRect().Y
+			where.Y -= m.items[itemIndex].panel.FrameRect().Y
 		}
 		fr := m.popupPanel.FrameRect()
 		where.Height = fr.Height
@@ -268,10 +281,11 @@ func (m *menu) newPanel(forBar bool) *menuPanel {
 	if m.updater != nil {
 		m.updater(m)
 	}
+	children := make([]*Panel, 0, len(m.items))
 	for _, mi := range m.items {
 		mi.validate()
 		child := mi.newPanel()
-		content.AddChild(child)
+		children = append(children, child)
 		if !forBar {
 			child.SetLayoutData(&FlexLayoutData{
 				HAlign: align.Fill,
@@ -280,12 +294,38 @@ func (m *menu) newPanel(forBar bool) *menuPanel {
 			})
 		}
 	}
+	columns := 1
+	m.rows = 0
+	if !forBar {
+		columns = m.computeColumns(children)
+	}
+	if columns > 1 {
+		// Lay the items out in column-major order, padding any unused cells in the last column with empty panels.
+		m.rows = (len(children) + columns - 1) / columns
+		for row := range m.rows {
+			for col := range columns {
+				if i := col*m.rows + row; i < len(children) {
+					content.AddChild(children[i])
+				} else {
+					content.AddChild(NewPanel())
+				}
+			}
+		}
+	} else {
+		for _, child := range children {
+			content.AddChild(child)
+		}
+	}
 	p.KeyDownCallback = func(keyCode KeyCode, mod Modifiers, _ bool) bool {
 		if mod != 0 {
 			return false
 		}
 		switch keyCode {
 		case KeyDown:
+			if m.rows > 0 {
+				m.moveInGrid(1, true)
+				return true
+			}
 			old := m.popupPanel.itemIndex
 			next := old
 			for {
@@ -304,6 +344,10 @@ func (m *menu) newPanel(forBar bool) *menuPanel {
 			m.doExitEnter(old)
 			return true
 		case KeyUp:
+			if m.rows > 0 {
+				m.moveInGrid(-1, true)
+				return true
+			}
 			old := m.popupPanel.itemIndex
 			next := old
 			for {
@@ -330,16 +374,22 @@ func (m *menu) newPanel(forBar bool) *menuPanel {
 				return true
 			}
 		case KeyEscape, KeyLeft:
+			if keyCode == KeyLeft && m.rows > 0 && m.moveInGrid(-m.rows, false) {
+				return true
+			}
 			m.closeMenuStackStoppingAt(p.Window(), m.titleItem.menu)
 			return true
 		case KeyRight:
+			if m.rows > 0 && m.moveInGrid(m.rows, false) {
+				return true
+			}
 			if m.popupPanel.itemIndex >= 0 && m.popupPanel.itemIndex < len(m.items) && m.items[m.popupPanel.itemIndex].subMenu != nil {
 				m.doExitEnter(-1)
 			}
 		}
 		return false
 	}
-	lay := &FlexLayout{Columns: 1}
+	lay := &FlexLayout{Columns: columns}
 	if forBar {
 		lay.Columns = len(content.Children())
 	}
@@ -358,6 +408,65 @@ func (m *menu) newPanel(forBar bool) *menuPanel {
 	return p
 }
 
+// setColumns sets the number of columns the popup presentation of this menu should use. A value of 0 for columns will
+// cause the number of columns to be computed from the item heights and maxColumnHeight. A maxColumnHeight of 0 means
+// there is no limit.
+func (m *menu) setColumns(columns int, maxColumnHeight float32) {
+	m.columns = columns
+	m.maxColumnHeight = maxColumnHeight
+}
+
+func (m *menu) computeColumns(children []*Panel) int {
+	count := len(children)
+	if count == 0 {
+		return 1
+	}
+	if m.columns > 0 {
+		return min(m.columns, count)
+	}
+	if m.maxColumnHeight <= 0 {
+		return 1
+	}
+	var height float32
+	for _, child := range children {
+		_, pref, _ := child.Sizes(Size{})
+		height = max(height, pref.Height)
+	}
+	if height <= 0 {
+		return 1
+	}
+	rows := max(int(m.maxColumnHeight/height), 1)
+	return (count + rows - 1) / rows
+}
+
+// moveInGrid moves the key index by delta items within a multi-column popup, skipping separators. When vertical is
+// true, movement is restricted to the current column. Returns false if no movement was possible.
+func (m *menu) moveInGrid(delta int, vertical bool) bool {
+	old := m.popupPanel.itemIndex
+	if old < 0 || old >= len(m.items) {
+		for i, mi := range m.items {
+			if !mi.isSeparator {
+				m.popupPanel.itemIndex = i
+				m.doExitEnter(old)
+				return true
+			}
+		}
+		return false
+	}
+	column := old / m.rows
+	for next := old + delta; next >= 0 && next < len(m.items); next += delta {
+		if vertical && next/m.rows != column {
+			break
+		}
+		if !m.items[next].isSeparator {
+			m.popupPanel.itemIndex = next
+			m.doExitEnter(old)
+			return true
+		}
+	}
+	return false
+}
+
 func (m *menu) doExitEnter(previousIndex int) {
 	if previousIndex != m.popupPanel.itemIndex && m.popupPanel.itemIndex >= 0 && m.popupPanel.itemIndex < len(m.items) {
 		if previousIndex >= 0 && previousIndex < len(m.items) {
@@ -19,7 +19,10 @@ import (
 	"github.com/richardwilkes/unison/enums/side"
 )
 
-var _ MenuItem = &menuItem{}
+var (
+	_ MenuItem           = &menuItem{}
+	_ MenuItemIconSetter = &menuItem{}
+)
 
 // MenuItem describes a choice that can be made from a Menu.
 type MenuItem interface {
@@ -51,6 +54,12 @@ type MenuItem interface {
 	SetCheckState(s check.Enum)
 }
 
+// MenuItemIconSetter defines the methods that may be implemented by a MenuItem that is able to show an icon.
+type MenuItemIconSetter interface {
+	// SetIcon sets the icon shown with the menu item. Pass nil to remove it.
+	SetIcon(icon Drawable)
+}
+
 // DefaultMenuItemTheme holds the default MenuItemTheme values for menu items. Modifying this data will not alter
 // existing menu items, but will alter any menu items created in the future.
 var DefaultMenuItemTheme = MenuItemTheme{
@@ -79,19 +88,22 @@ type MenuItemTheme struct {
 }
 
 type menuItem struct {
-	factory     *inWindowMenuFactory
-	menu        *menu
-	subMenu     *menu
-	panel       *Panel
-	validator   func(MenuItem) bool
-	handler     func(MenuItem)
-	title       string
-	id          int
-	keyBinding  KeyBinding
-	state       check.Enum
-	isSeparator bool
-	enabled     bool
-	over        bool
+	factory        *inWindowMenuFactory
+	menu           *menu
+	subMenu        *menu
+	panel          *Panel
+	validator      func(MenuItem) bool
+	handler        func(MenuItem)
+	icon           Drawable
+	title          string
+	disabledReason string
+	id             int
+	keyBinding     KeyBinding
+	state          check.Enum
+	isSeparator    bool
+	enabled        bool
+	over           bool
+	keepOpen       bool
 }
 
 func (mi *menuItem) Factory() MenuFactory {
@@ -158,6 +170,10 @@ func (mi *menuItem) SetCheckState(s check.Enum) {
 	mi.state = s
 }
 
+func (mi *menuItem) SetIcon(icon Drawable) {
+	mi.icon = icon
+}
+
 func (mi *menuItem) newPanel() *Panel {
 	mi.panel = NewPanel()
 	if mi.isSeparator {
@@ -173,6 +189,9 @@ func (mi *menuItem) newPanel() *Panel {
 	mi.panel.MouseDownCallback = mi.mouseDown
 	mi.panel.MouseUpCallback = mi.mouseUp
 	mi.panel.SetSizer(mi.sizer)
+	if !mi.isSeparator && !mi.enabled && mi.disabledReason != "" {
+		mi.panel.Tooltip = NewTooltipWithText(mi.disabledReason)
+	}
 	return mi.panel
 }
 
@@ -257,6 +276,10 @@ func (mi *menuItem) mouseExit() bool {
 func (mi *menuItem) sizer(hint Size) (minSize, prefSize, maxSize Size) {
 	if mi.isSeparator {
 		prefSize.Height = 1
+		if mi.title != "" {
+			prefSize = mi.captionText(DefaultMenuItemTheme.OnBackgroundColor).Extents()
+			prefSize.Width += DefaultMenuItemTheme.KeyGap
+		}
 	} else {
 		prefSize, _ = LabelContentSizes(NewText(mi.Title(), &TextDecoration{
 			Font:            DefaultMenuItemTheme.TitleFont,
@@ -281,6 +304,13 @@ func (mi *menuItem) sizer(hint Size) (minSize, prefSize, maxSize Size) {
 	return prefSize, prefSize, prefSize
 }
 
+func (mi *menuItem) captionText(ink Ink) *Text {
+	return NewText(mi.title, &TextDecoration{
+		Font:            DefaultMenuItemTheme.KeyFont,
+		OnBackgroundInk: ink,
+	})
+}
+
 func (mi *menuItem) paint(gc *Canvas, rect Rect) {
 	var fg, bg Ink
 	if !mi.over || !mi.enabled {
@@ -300,7 +330,16 @@ func (mi *menuItem) paint(gc *Canvas, rect Rect) {
 	}
 	rect = mi.panel.ContentRect(false)
 	if mi.isSeparator {
-		gc.DrawLine(rect.X, rect.Y, rect.Right(), rect.Y, fg.Paint(gc, rect, paintstyle.Fill))
+		if mi.title != "" {
+			caption := mi.captionText(fg)
+			size := caption.Extents()
+			caption.Draw(gc, rect.X, rect.Y+(rect.Height-size.Height)/2+caption.Baseline())
+			y := rect.CenterY()
+			gc.DrawLine(rect.X+size.Width+DefaultMenuItemTheme.KeyFont.Baseline()/2, y, rect.Right(), y,
+				fg.Paint(gc, rect, paintstyle.Fill))
+		} else {
+			gc.DrawLine(rect.X, rect.Y, rect.Right(), rect.Y, fg.Paint(gc, rect, paintstyle.Fill))
+		}
 	} else {
 		t := NewText(mi.Title(), &TextDecoration{
 			Font:            DefaultMenuItemTheme.TitleFont,
@@ -314,16 +353,19 @@ func (mi *menuItem) paint(gc *Canvas, rect Rect) {
 		}
 		t.Draw(gc, rect.X+shifted, xmath.Floor(rect.Y+(rect.Height-size.Height)/2)+t.Baseline())
 		if mi.subMenu == nil {
-			if !mi.isRoot() && mi.state != check.Off {
+			if !mi.isRoot() && (mi.state != check.Off || mi.icon != nil) {
 				r := rect
 				r.Width = baseline
 				r.Height = baseline
 				r.Y += (rect.Height - baseline) / 2
-				drawable := &DrawableSVG{Size: Size{Width: baseline, Height: baseline}}
-				if mi.state == check.On {
-					drawable.SVG = CheckmarkSVG
-				} else {
-					drawable.SVG = DashSVG
+				var drawable Drawable
+				switch mi.state {
+				case check.On:
+					drawable = &DrawableSVG{SVG: CheckmarkSVG, Size: Size{Width: baseline, Height: baseline}}
+				case check.Mixed:
+					drawable = &DrawableSVG{SVG: DashSVG, Size: Size{Width: baseline, Height: baseline}}
+				default:
+					drawable = mi.icon
 				}
 				drawable.DrawInRect(gc, r, nil, fg.Paint(gc, r, paintstyle.Fill))
 			}
@@ -374,6 +416,15 @@ func (mi *menuItem) execute() {
 	if mi.isSeparator {
 		return
 	}
+	if mi.keepOpen {
+		if mi.enabled && mi.handler != nil {
+			toolbox.Call(func() { mi.handler(mi) })
+			if mi.panel != nil {
+				mi.panel.MarkForRedraw()
+			}
+		}
+		return
+	}
 	mi.menu.closeMenuStack()
 	if mi.enabled && mi.handler != nil {
 		toolbox.Call(func() { mi.handler(mi) })
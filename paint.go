@@ -53,6 +53,16 @@ func (p *Paint) Clone() *Paint {
 	return newPaint(skia.PaintClone(p.paint))
 }
 
+// Dispose releases the underlying resources immediately, rather than waiting for the garbage collector to do so. The
+// paint must not be used after this call. Must be called on the UI thread.
+func (p *Paint) Dispose() {
+	if p.paint != nil {
+		runtime.SetFinalizer(p, nil)
+		skia.PaintDelete(p.paint)
+		p.paint = nil
+	}
+}
+
 // Equivalent returns true if these Paint objects are equivalent.
 func (p *Paint) Equivalent(other *Paint) bool {
 	if p == nil {
@@ -99,6 +109,11 @@ func (p *Paint) SetColor(color Color) {
 	skia.PaintSetColor(p.paint, skia.Color(color))
 }
 
+// SetColorWithAlpha sets the color, replacing its alpha channel with the given value.
+func (p *Paint) SetColorWithAlpha(color Color, alpha byte) {
+	p.SetColor(color.SetAlpha(int(alpha)))
+}
+
 // Style returns the current PaintStyle.
 func (p *Paint) Style() paintstyle.Enum {
 	return paintstyle.Enum(skia.PaintGetStyle(p.paint))
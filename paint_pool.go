@@ -0,0 +1,55 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import "github.com/richardwilkes/unison/enums/paintstyle"
+
+// Only accessed on the UI thread, so no locking is required.
+var paintPool []*Paint
+
+// WithPaint calls f with a Paint taken from a pool of reusable paints. The paint is in its default state, as returned
+// by NewPaint, and is returned to the pool once f returns, so it must not be retained beyond the call. This avoids the
+// allocation and finalizer registration that NewPaint incurs, making it suitable for hot drawing paths. Must be called
+// on the UI thread.
+func WithPaint(f func(paint *Paint)) {
+	var paint *Paint
+	if last := len(paintPool) - 1; last >= 0 {
+		paint = paintPool[last]
+		paintPool[last] = nil
+		paintPool = paintPool[:last]
+		paint.Reset()
+		paint.SetAntialias(true)
+	} else {
+		paint = NewPaint()
+	}
+	defer func() { paintPool = append(paintPool, paint) }()
+	f(paint)
+}
+
+// WithInkPaint calls f with a Paint for the ink, as would be returned by ink.Paint(canvas, rect, style). When the ink
+// is a ColorProvider, the paint comes from the same pool used by WithPaint and must not be retained beyond the call.
+// Must be called on the UI thread.
+func WithInkPaint(canvas *Canvas, rect Rect, ink Ink, style paintstyle.Enum, f func(paint *Paint)) {
+	if cp, ok := ink.(ColorProvider); ok {
+		WithPaint(func(paint *Paint) {
+			paint.SetStyle(style)
+			paint.SetColor(cp.GetColor())
+			f(paint)
+		})
+		return
+	}
+	f(ink.Paint(canvas, rect, style))
+}
+
+// FillRectWithInk fills the rectangle with the ink, using a pooled Paint when possible. Must be called on the UI
+// thread.
+func (c *Canvas) FillRectWithInk(rect Rect, ink Ink) {
+	WithInkPaint(c, rect, ink, paintstyle.Fill, func(paint *Paint) { c.DrawRect(rect, paint) })
+}
@@ -15,6 +15,8 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/enums/pathop"
 )
 
@@ -43,6 +45,9 @@ type Panel struct {
 	ScrollRectIntoViewCallback          func(rect Rect) bool
 	ParentChangedCallback               func()
 	FocusChangeInHierarchyCallback      func(from, to *Panel)
+	// AccessibilityCallback returns the role, name, value and state of the panel, as reported to assistive
+	// technologies. See Accessibility().
+	AccessibilityCallback func() AccessibilityInfo
 	// DataDragOverCallback is called when a data drag is over a potential drop target. Return true to stop further
 	// handling or false to propagate up to parents.
 	DataDragOverCallback func(where Point, data map[string]any) bool
@@ -54,6 +59,8 @@ type Panel struct {
 	DataDragDropCallback func(where Point, data map[string]any)
 	Tooltip              *Panel
 	parent               *Panel
+	nextFocusTarget      *Panel
+	keyBindingHook       func(keyCode KeyCode, mod Modifiers) bool
 	canPerformMap        map[int]func(any) bool
 	performMap           map[int]func(any)
 	data                 map[string]any
@@ -61,6 +68,7 @@ type Panel struct {
 	children             []*Panel
 	frame                Rect
 	scale                float32
+	tabIndex             int
 	NeedsLayout          bool
 	focusable            bool
 	disabled             bool
@@ -423,6 +431,25 @@ func (p *Panel) FlushDrawing() {
 	}
 }
 
+// SnapshotToImage renders this panel and its children into a new image, independent of the normal paint cycle. The
+// image will have the logical size of the panel's frame, with scale determining the pixel density, e.g. a scale of 2
+// produces an image with twice as many pixels in each dimension. On-screen state is not modified, other than to
+// ensure the panel's layout is valid.
+func (p *Panel) SnapshotToImage(scale float32) (*Image, error) {
+	if scale <= 0 {
+		return nil, errs.New("scale must be greater than 0")
+	}
+	p.ValidateLayout()
+	size := p.FrameRect().Size
+	if size.Width <= 0 || size.Height <= 0 {
+		return nil, errs.New("panel has no area to draw")
+	}
+	return NewImageFromDrawing(int(xmath.Ceil(size.Width)), int(xmath.Ceil(size.Height)),
+		int(xmath.Round(scale*72)), func(canvas *Canvas) {
+			p.Draw(canvas, Rect{Size: p.frame.Size})
+		})
+}
+
 // Draw is called by its owning window when a panel needs to be drawn. The canvas has already had its clip set to rect.
 func (p *Panel) Draw(gc *Canvas, rect Rect) {
 	if p.Hidden {
@@ -479,6 +506,24 @@ func (p *Panel) SetEnabled(enabled bool) {
 	}
 }
 
+// handleKeyBindingHooks gives the key binding hooks of this panel and its descendants the chance to handle a key down
+// event, returning the panel whose hook handled it, if any. Hooks of panels that aren't enabled are skipped.
+func (p *Panel) handleKeyBindingHooks(keyCode KeyCode, mod Modifiers) *Panel {
+	if p.keyBindingHook != nil && p.Enabled() {
+		stop := false
+		toolbox.Call(func() { stop = p.keyBindingHook(keyCode, mod) })
+		if stop {
+			return p
+		}
+	}
+	for _, child := range p.children {
+		if panel := child.handleKeyBindingHooks(keyCode, mod); panel != nil {
+			return panel
+		}
+	}
+	return nil
+}
+
 // Focusable returns true if this panel can have the keyboard focus.
 func (p *Panel) Focusable() bool {
 	return p.focusable && p.Enabled()
@@ -506,6 +551,33 @@ func (p *Panel) RequestFocus() {
 	}
 }
 
+// TabIndex returns the tab index of this panel. See SetTabIndex().
+func (p *Panel) TabIndex() int {
+	return p.tabIndex
+}
+
+// SetTabIndex sets the tab index of this panel. Focusable panels with a positive tab index are visited first during
+// keyboard focus traversal, in ascending order, followed by those with a tab index of zero or less, in the order they
+// appear within the panel hierarchy.
+func (p *Panel) SetTabIndex(index int) {
+	p.tabIndex = index
+}
+
+// NextFocusTarget returns the panel that keyboard focus traversal will move to from this panel, if one has been set.
+func (p *Panel) NextFocusTarget() *Panel {
+	return p.nextFocusTarget
+}
+
+// SetNextFocusTarget sets the panel that keyboard focus traversal will move to from this panel, overriding the normal
+// order. Traversing backwards from the target returns to this panel. Pass nil to restore the normal order.
+func (p *Panel) SetNextFocusTarget(target Paneler) {
+	if target == nil {
+		p.nextFocusTarget = nil
+	} else {
+		p.nextFocusTarget = target.AsPanel()
+	}
+}
+
 // FirstFocusableChild returns the first focusable child or nil.
 func (p *Panel) FirstFocusableChild() *Panel {
 	for _, child := range p.children {
@@ -20,6 +20,8 @@ import (
 	"github.com/richardwilkes/unison/internal/skia"
 )
 
+const pathSerializationVersion = 2
+
 // PathOpPair holds the combination of a Path and a PathOp.
 type PathOpPair struct {
 	Path *Path
@@ -46,6 +48,17 @@ func NewPath() *Path {
 	return newPath(skia.PathNew())
 }
 
+// Dispose releases the underlying resources immediately, rather than waiting for the garbage collector to do so. This
+// is useful when large numbers of transient paths are being created. The path must not be used after this call. Must be
+// called on the UI thread.
+func (p *Path) Dispose() {
+	if p.path != nil {
+		runtime.SetFinalizer(p, nil)
+		skia.PathDelete(p.path)
+		p.path = nil
+	}
+}
+
 // NewPathFromSVGString attempts to create a path from the given SVG string.
 func NewPathFromSVGString(svg string) (*Path, error) {
 	p := NewPath()
@@ -62,6 +75,27 @@ func (p *Path) ToSVGString(useAbsoluteValues bool) string {
 	return skia.StringGetString(ss)
 }
 
+// Serialize returns a compact binary representation of this path, including its fill type and the exact verbs,
+// points, and conic weights. Use DeserializePath() to recreate the path.
+func (p *Path) Serialize() []byte {
+	return append([]byte{pathSerializationVersion}, skia.PathSerialize(p.path)...)
+}
+
+// DeserializePath creates a new path from data previously created by a call to Serialize().
+func DeserializePath(data []byte) (*Path, error) {
+	if len(data) < 2 {
+		return nil, errs.New("path data is too short")
+	}
+	if data[0] != pathSerializationVersion {
+		return nil, errs.Newf("unsupported path data version: %d", data[0])
+	}
+	p := NewPath()
+	if !skia.PathDeserialize(p.path, data[1:]) {
+		return nil, errs.New("invalid path data")
+	}
+	return p, nil
+}
+
 // FillType returns the FillType for this path.
 func (p *Path) FillType() filltype.Enum {
 	return filltype.Enum(skia.PathGetFillType(p.path))
@@ -95,6 +129,14 @@ func (p *Path) ArcToOval(bounds Rect, startAngle, sweepAngle float32, forceMoveT
 	skia.PathArcToWithOval(p.path, bounds, startAngle, sweepAngle, forceMoveTo)
 }
 
+// ArcCentered appends an arc of a circle centered at (cx, cy) with the given radius. Both startDegrees and sweepDegrees
+// are in degrees. A positive sweepDegrees extends clockwise while a negative value extends counter-clockwise. If
+// moveToStart is true, a new contour is started at the beginning of the arc, otherwise a line is added from the current
+// point to the start of the arc.
+func (p *Path) ArcCentered(cx, cy, radius, startDegrees, sweepDegrees float32, moveToStart bool) {
+	p.ArcToOval(NewRect(cx-radius, cy-radius, radius*2, radius*2), startDegrees, sweepDegrees, moveToStart)
+}
+
 // Bounds returns the bounding rectangle of the path. This is an approximation and may be different than the actual area
 // covered when drawn.
 func (p *Path) Bounds() Rect {
@@ -308,6 +350,48 @@ func (p *Path) NewTranslated(x, y float32) *Path {
 	return path
 }
 
+// Stroked creates a new path representing the outline of the area that would be covered by stroking this path with the
+// provided paint, taking into account its stroke width, cap, join, miter and any path effect, such as dashing. The
+// paint should have a stroke style set, otherwise the result will simply be a copy of this path. This path is left
+// unmodified.
+func (p *Path) Stroked(paint *Paint) *Path {
+	path, _ := paint.FillPath(p, 1)
+	return path
+}
+
+// Flatten returns a polyline approximation of this path, with one contour per sub-path. Curves are subdivided until
+// no line segment deviates from the curve by more than tolerance. A tolerance of 0 or less uses a default of 0.1.
+// Contours in a Polygon are implicitly closed, so open sub-paths will be treated as closed by consumers of the result.
+func (p *Path) Flatten(tolerance float32) Polygon {
+	contours := p.flatten(tolerance)
+	result := make(Polygon, 0, len(contours))
+	for _, contour := range contours {
+		pts := contour.pts
+		if len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+			pts = pts[:len(pts)-1]
+		}
+		if len(pts) > 0 {
+			result = append(result, pts)
+		}
+	}
+	return result
+}
+
+// Area returns the signed area of the region that would be filled by this path, taking into account the FillType
+// for self-intersecting shapes. Open contours are treated as if they were closed, just as they are when filled. An
+// empty path returns 0. Inverse fill types are not considered.
+func (p *Path) Area() float32 {
+	area, _ := p.massProperties()
+	return float32(area)
+}
+
+// Centroid returns the center of mass of the region that would be filled by this path, taking into account the
+// FillType for self-intersecting shapes. If the path encloses no area, the center of its bounds is returned instead.
+func (p *Path) Centroid() Point {
+	_, centroid := p.massProperties()
+	return centroid
+}
+
 // Reset the path, as if it was newly created.
 func (p *Path) Reset() {
 	skia.PathReset(p.path)
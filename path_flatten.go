@@ -0,0 +1,228 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// defaultFlattenTolerance is the maximum distance a flattened line segment is allowed to deviate from the curve it
+// approximates when no explicit tolerance is provided.
+const defaultFlattenTolerance = 0.1
+
+type pathVerb byte
+
+const (
+	pathVerbMove pathVerb = iota
+	pathVerbLine
+	pathVerbQuad
+	pathVerbCubic
+	pathVerbClose
+)
+
+// pathSegment holds a single verb of a path along with the absolute points it uses. Moves and lines have one point,
+// quads have two, cubics have three and closes have none.
+type pathSegment struct {
+	pts  []Point
+	verb pathVerb
+}
+
+type flatContour struct {
+	pts    []Point
+	closed bool
+}
+
+// segments returns the verbs of the path. The skia C API does not expose a path iterator, so the absolute SVG form of
+// the path is used as the source of the verbs. Conics are reduced to quads by that conversion.
+func (p *Path) segments() []pathSegment {
+	var segs []pathSegment
+	var args []float32
+	var verb byte
+	emit := func() {
+		switch verb {
+		case 'M':
+			for len(args) >= 2 {
+				segs = append(segs, pathSegment{verb: pathVerbMove, pts: []Point{{X: args[0], Y: args[1]}}})
+				args = args[2:]
+				verb = 'L' // Subsequent pairs are implicit line-to's
+			}
+		case 'L':
+			for len(args) >= 2 {
+				segs = append(segs, pathSegment{verb: pathVerbLine, pts: []Point{{X: args[0], Y: args[1]}}})
+				args = args[2:]
+			}
+		case 'Q':
+			for len(args) >= 4 {
+				segs = append(segs, pathSegment{
+					verb: pathVerbQuad,
+					pts:  []Point{{X: args[0], Y: args[1]}, {X: args[2], Y: args[3]}},
+				})
+				args = args[4:]
+			}
+		case 'C':
+			for len(args) >= 6 {
+				segs = append(segs, pathSegment{
+					verb: pathVerbCubic,
+					pts:  []Point{{X: args[0], Y: args[1]}, {X: args[2], Y: args[3]}, {X: args[4], Y: args[5]}},
+				})
+				args = args[6:]
+			}
+		case 'Z':
+			segs = append(segs, pathSegment{verb: pathVerbClose})
+		}
+		args = args[:0]
+	}
+	s := p.ToSVGString(true)
+	i := 0
+	for i < len(s) {
+		ch := s[i]
+		switch {
+		case ch == 'M' || ch == 'L' || ch == 'Q' || ch == 'C' || ch == 'Z' || ch == 'z':
+			emit()
+			verb = ch
+			if verb == 'z' || verb == 'Z' {
+				verb = 'Z'
+				emit()
+				verb = 0
+			}
+			i++
+		case ch == '-' || ch == '+' || ch == '.' || (ch >= '0' && ch <= '9'):
+			j := i + 1
+			for j < len(s) {
+				c := s[j]
+				if (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' ||
+					((c == '-' || c == '+') && (s[j-1] == 'e' || s[j-1] == 'E')) {
+					j++
+					continue
+				}
+				break
+			}
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(s[i:j], "+"), 32); err == nil {
+				args = append(args, float32(v))
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	emit()
+	return segs
+}
+
+// flatten converts the path into contours made up of straight line segments, subdividing curves such that no segment
+// deviates from the curve by more than tolerance.
+func (p *Path) flatten(tolerance float32) []flatContour {
+	if tolerance <= 0 {
+		tolerance = defaultFlattenTolerance
+	}
+	var contours []flatContour
+	var current *flatContour
+	var start, last Point
+	ensureContour := func() {
+		if current == nil {
+			contours = append(contours, flatContour{pts: []Point{last}})
+			current = &contours[len(contours)-1]
+		}
+	}
+	for _, seg := range p.segments() {
+		switch seg.verb {
+		case pathVerbMove:
+			current = nil
+			last = seg.pts[0]
+			start = last
+			ensureContour()
+		case pathVerbLine:
+			ensureContour()
+			last = seg.pts[0]
+			current.pts = append(current.pts, last)
+		case pathVerbQuad:
+			ensureContour()
+			current.pts = flattenQuad(current.pts, last, seg.pts[0], seg.pts[1], tolerance)
+			last = seg.pts[1]
+		case pathVerbCubic:
+			ensureContour()
+			current.pts = flattenCubic(current.pts, last, seg.pts[0], seg.pts[1], seg.pts[2], tolerance)
+			last = seg.pts[2]
+		case pathVerbClose:
+			if current != nil {
+				current.closed = true
+				current = nil
+			}
+			last = start
+		}
+	}
+	return contours
+}
+
+func flattenQuad(pts []Point, p0, p1, p2 Point, tolerance float32) []Point {
+	dd := math.Hypot(float64(p0.X-2*p1.X+p2.X), float64(p0.Y-2*p1.Y+p2.Y))
+	n := max(int(math.Ceil(math.Sqrt(dd/(4*float64(tolerance))))), 1)
+	for i := 1; i <= n; i++ {
+		t := float32(i) / float32(n)
+		mt := 1 - t
+		a := mt * mt
+		b := 2 * mt * t
+		c := t * t
+		pts = append(pts, Point{X: a*p0.X + b*p1.X + c*p2.X, Y: a*p0.Y + b*p1.Y + c*p2.Y})
+	}
+	return pts
+}
+
+func flattenCubic(pts []Point, p0, p1, p2, p3 Point, tolerance float32) []Point {
+	dd := max(math.Hypot(float64(p0.X-2*p1.X+p2.X), float64(p0.Y-2*p1.Y+p2.Y)),
+		math.Hypot(float64(p1.X-2*p2.X+p3.X), float64(p1.Y-2*p2.Y+p3.Y)))
+	n := max(int(math.Ceil(math.Sqrt(3*dd/(4*float64(tolerance))))), 1)
+	for i := 1; i <= n; i++ {
+		t := float32(i) / float32(n)
+		mt := 1 - t
+		a := mt * mt * mt
+		b := 3 * mt * mt * t
+		c := 3 * mt * t * t
+		d := t * t * t
+		pts = append(pts, Point{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		})
+	}
+	return pts
+}
+
+// massProperties returns the signed area and the area-weighted centroid of the region that would be filled by the
+// path. The path is first simplified so that self-intersections and the fill type are resolved into non-overlapping
+// contours.
+func (p *Path) massProperties() (area float64, centroid Point) {
+	path := p.Clone()
+	defer path.Dispose()
+	path.Simplify()
+	var cx, cy float64
+	for _, contour := range path.flatten(defaultFlattenTolerance) {
+		count := len(contour.pts)
+		if count < 3 {
+			continue
+		}
+		for i, pt := range contour.pts {
+			next := contour.pts[(i+1)%count]
+			x0, y0 := float64(pt.X), float64(pt.Y)
+			x1, y1 := float64(next.X), float64(next.Y)
+			cross := x0*y1 - x1*y0
+			area += cross
+			cx += (x0 + x1) * cross
+			cy += (y0 + y1) * cross
+		}
+	}
+	area /= 2
+	if area == 0 {
+		r := p.ComputeTightBounds()
+		return 0, Point{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+	}
+	return area, Point{X: float32(cx / (6 * area)), Y: float32(cy / (6 * area))}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/filltype"
+)
+
+func TestPathSerialization(t *testing.T) {
+	p := unison.NewPath()
+	p.SetFillType(filltype.EvenOdd)
+	p.MoveTo(10, 10)
+	p.LineTo(100.5, 10)
+	p.QuadTo(120, 30, 100.5, 50)
+	p.ConicTo(80, 70, 50, 60, 0.707)
+	p.CubicTo(40, 55, 20, 45.25, 10, 30)
+	p.Close()
+	p.MoveTo(200, 200)
+	p.LineTo(250, 275)
+
+	data := p.Serialize()
+	p2, err := unison.DeserializePath(data)
+	check.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	check.Equal(t, p.ToSVGString(true), p2.ToSVGString(true))
+	check.Equal(t, p.FillType(), p2.FillType())
+	check.Equal(t, data, p2.Serialize())
+
+	empty, err := unison.DeserializePath(unison.NewPath().Serialize())
+	check.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	check.Equal(t, "", empty.ToSVGString(true))
+
+	_, err = unison.DeserializePath(data[:len(data)-3])
+	check.Error(t, err)
+	_, err = unison.DeserializePath(nil)
+	check.Error(t, err)
+}
+
+func TestPathSerializationKeepsConicWeight(t *testing.T) {
+	p := unison.NewPath()
+	p.MoveTo(0, 0)
+	p.ConicTo(100, 0, 100, 100, 0.25)
+	data := p.Serialize()
+	p2, err := unison.DeserializePath(data)
+	check.NoError(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	check.Equal(t, data, p2.Serialize())
+	check.Equal(t, p.ComputeTightBounds(), p2.ComputeTightBounds())
+
+	// A different weight must produce different data, so the weight is actually being recorded
+	other := unison.NewPath()
+	other.MoveTo(0, 0)
+	other.ConicTo(100, 0, 100, 100, 2)
+	check.NotEqual(t, data, other.Serialize())
+	check.NotEqual(t, p.ComputeTightBounds(), other.ComputeTightBounds())
+}
@@ -10,13 +10,17 @@
 package unison
 
 import (
+	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 	"github.com/richardwilkes/unison/enums/tilemode"
 )
 
 var _ Ink = &Pattern{}
 
-// Pattern holds the information necessary to draw an image in a pattern.
+// Pattern holds the information necessary to draw an image in a pattern. By default, the pattern is anchored to the
+// origin of the canvas. If AnchorToRect is true, the pattern is instead anchored to the area being painted, with HAlign
+// and VAlign determining where within that area the first tile is placed, so that the pattern moves with it. Offset is
+// applied after anchoring.
 type Pattern struct {
 	Image           *Image
 	Offset          Point
@@ -24,10 +28,13 @@ type Pattern struct {
 	TileModeX       tilemode.Enum
 	TileModeY       tilemode.Enum
 	SamplingOptions SamplingOptions
+	HAlign          align.Enum
+	VAlign          align.Enum
+	AnchorToRect    bool
 }
 
 // Paint returns a Paint for this Pattern.
-func (p *Pattern) Paint(canvas *Canvas, _ Rect, style paintstyle.Enum) *Paint {
+func (p *Pattern) Paint(canvas *Canvas, rect Rect, style paintstyle.Enum) *Paint {
 	paint := NewPaint()
 	paint.SetStyle(style)
 	scale := p.Scale
@@ -38,13 +45,19 @@ func (p *Pattern) Paint(canvas *Canvas, _ Rect, style paintstyle.Enum) *Paint {
 		scale.Y = 1
 	}
 	imgScale := p.Image.Scale()
+	offset := p.Offset
+	if p.AnchorToRect {
+		size := p.Image.LogicalSize()
+		offset.X += rect.X + alignOverlay(p.HAlign, rect.Width, size.Width*scale.X)
+		offset.Y += rect.Y + alignOverlay(p.VAlign, rect.Height, size.Height*scale.Y)
+	}
 	paint.SetColor(Black)
 	paint.SetShader(NewImageShader(canvas, p.Image, p.TileModeX, p.TileModeY, &p.SamplingOptions,
 		Matrix{
 			ScaleX: scale.X * imgScale,
 			ScaleY: scale.Y * imgScale,
-			TransX: p.Offset.X,
-			TransY: p.Offset.Y,
+			TransX: offset.X,
+			TransY: offset.Y,
 		}))
 	return paint
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/check"
 	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/role"
 	"github.com/richardwilkes/unison/enums/slant"
 )
 
@@ -43,15 +44,24 @@ type PopupMenuTheme struct {
 	SelectionInk   Ink
 	OnSelectionInk Ink
 	TextDecoration
-	CornerRadius float32
-	HMargin      float32
-	VMargin      float32
+	// Columns is the number of columns to lay the items out in when the menu is shown. A value of 0 will compute the
+	// number of columns from the item count and MaxColumnHeight. Native menus may ignore this.
+	Columns int
+	// MaxColumnHeight is the maximum height of a column when Columns is 0. A value of 0 means there is no limit, which
+	// results in a single column.
+	MaxColumnHeight float32
+	CornerRadius    float32
+	HMargin         float32
+	VMargin         float32
 }
 
 type popupMenuItem[T comparable] struct {
-	item      T
-	enabled   bool
-	separator bool
+	item           T
+	keyBinding     KeyBinding
+	disabledReason string
+	caption        string
+	enabled        bool
+	separator      bool
 }
 
 // PopupMenu represents a clickable button that displays a menu of choices.
@@ -60,11 +70,23 @@ type PopupMenu[T comparable] struct {
 	WillShowMenuCallback     func(popup *PopupMenu[T])
 	ChoiceMadeCallback       func(popup *PopupMenu[T], index int, item T)
 	SelectionChangedCallback func(popup *PopupMenu[T])
-	items                    []*popupMenuItem[T]
-	selection                map[int]bool
+	// SelectionCallback, if set, is called with the full set of selected indexes when the menu is dismissed while in
+	// MultiSelect mode.
+	SelectionCallback func(popup *PopupMenu[T], indexes []int)
+	items             []*popupMenuItem[T]
+	selection         map[int]bool
+	sizeCacheFont     Font
 	PopupMenuTheme
 	Panel
-	pressed bool
+	sizeCache Size
+	// WrapKeyNavigation causes keyboard navigation of a closed PopupMenu to wrap around when moving past either end.
+	WrapKeyNavigation bool
+	// MultiSelect causes clicking an item in the open menu to toggle its checked state rather than replacing the
+	// selection. ChoiceMadeCallback is not called in this mode; changes are reported via SelectionChangedCallback and
+	// SelectionCallback instead. When the in-window menus are in use, the menu also stays open until dismissed.
+	MultiSelect    bool
+	pressed        bool
+	sizeCacheValid bool
 }
 
 // NewPopupMenu creates a new PopupMenu.
@@ -85,27 +107,42 @@ func NewPopupMenu[T comparable]() *PopupMenu[T] {
 	p.MouseUpCallback = p.DefaultMouseUp
 	p.KeyDownCallback = p.DefaultKeyDown
 	p.UpdateCursorCallback = p.DefaultUpdateCursor
+	p.AccessibilityCallback = p.DefaultAccessibility
+	p.keyBindingHook = p.HandleKeyBinding
 	p.ChoiceMadeCallback = func(popup *PopupMenu[T], index int, _ T) { popup.SelectIndex(index) }
 	return p
 }
 
+// DefaultAccessibility provides the default accessibility information.
+func (p *PopupMenu[T]) DefaultAccessibility() AccessibilityInfo {
+	return AccessibilityInfo{
+		Value: p.Text(),
+		Role:  role.PopupMenu,
+	}
+}
+
 // DefaultSizes provides the default sizing.
 func (p *PopupMenu[T]) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
-	prefSize, _ = LabelContentSizes(nil, nil, p.Font, 0, 0)
-	for _, one := range p.items {
-		if !one.separator {
-			size, _ := LabelContentSizes(NewText(fmt.Sprintf("%v", one.item), &TextDecoration{
-				Font:            p.Font,
-				OnBackgroundInk: p.OnBackgroundInk,
-			}), nil, p.Font, 0, 0)
-			if prefSize.Width < size.Width {
-				prefSize.Width = size.Width
-			}
-			if prefSize.Height < size.Height {
-				prefSize.Height = size.Height
+	if !p.sizeCacheValid || p.sizeCacheFont != p.Font {
+		p.sizeCache, _ = LabelContentSizes(nil, nil, p.Font, 0, 0)
+		for _, one := range p.items {
+			if !one.separator {
+				size, _ := LabelContentSizes(NewText(fmt.Sprintf("%v", one.item), &TextDecoration{
+					Font:            p.Font,
+					OnBackgroundInk: p.OnBackgroundInk,
+				}), nil, p.Font, 0, 0)
+				if p.sizeCache.Width < size.Width {
+					p.sizeCache.Width = size.Width
+				}
+				if p.sizeCache.Height < size.Height {
+					p.sizeCache.Height = size.Height
+				}
 			}
 		}
+		p.sizeCacheFont = p.Font
+		p.sizeCacheValid = true
 	}
+	prefSize = p.sizeCache
 	if border := p.Border(); border != nil {
 		prefSize = prefSize.Add(border.Insets().Size())
 	}
@@ -117,6 +154,12 @@ func (p *PopupMenu[T]) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size)
 	return prefSize, prefSize, maxSize
 }
 
+// itemsChanged invalidates any cached sizing information and marks the PopupMenu for redraw.
+func (p *PopupMenu[T]) itemsChanged() {
+	p.sizeCacheValid = false
+	p.MarkForRedraw()
+}
+
 // DefaultFocusGained provides the default focus gained handling.
 func (p *PopupMenu[T]) DefaultFocusGained() {
 	p.ScrollIntoView()
@@ -127,12 +170,15 @@ func (p *PopupMenu[T]) DefaultFocusGained() {
 func (p *PopupMenu[T]) DefaultDraw(canvas *Canvas, _ Rect) {
 	thickness := float32(1)
 	edge := p.EdgeInk
-	if p.Focused() || p.pressed {
+	if p.pressed {
 		thickness++
 		edge = p.SelectionInk
 	}
 	rect := p.ContentRect(false)
 	DrawRoundedRectBase(canvas, rect, p.CornerRadius, thickness, p.BackgroundInk, edge)
+	if p.Focused() && !p.pressed && !ShowFocusRing {
+		DrawFocusRect(canvas, rect, p.SelectionInk)
+	}
 	rect = rect.Inset(NewUniformInsets(1.5))
 	rect.X += p.HMargin
 	rect.Y += p.VMargin
@@ -170,7 +216,7 @@ func (p *PopupMenu[T]) textObj() *Text {
 	default:
 		desc := p.Font.Descriptor()
 		desc.Slant = slant.Italic
-		return NewText(i18n.Text("Multiple"), &TextDecoration{
+		return NewText(p.multipleText(len(indexes)), &TextDecoration{
 			Font:            desc.Font(),
 			OnBackgroundInk: p.OnBackgroundInk,
 		})
@@ -186,10 +232,17 @@ func (p *PopupMenu[T]) Text() string {
 	case 1:
 		return fmt.Sprintf("%v", p.items[indexes[0]].item)
 	default:
-		return i18n.Text("Multiple")
+		return p.multipleText(len(indexes))
 	}
 }
 
+func (p *PopupMenu[T]) multipleText(count int) string {
+	if p.MultiSelect {
+		return fmt.Sprintf(i18n.Text("%d selected"), count)
+	}
+	return i18n.Text("Multiple")
+}
+
 // Click performs any animation associated with a click and triggers the popup menu to appear.
 func (p *PopupMenu[T]) Click() {
 	if p.WillShowMenuCallback != nil {
@@ -198,9 +251,21 @@ func (p *PopupMenu[T]) Click() {
 	hasItem := false
 	m := p.MenuFactory.NewMenu(PopupMenuTemporaryBaseID, "", nil)
 	defer m.Dispose()
+	if gm, ok := m.(*menu); ok {
+		gm.setColumns(p.Columns, p.MaxColumnHeight)
+		if p.MultiSelect {
+			gm.closedCallback = p.multiSelectDone
+		}
+	} else if p.MultiSelect {
+		defer p.multiSelectDone()
+	}
 	for i, one := range p.items {
 		if one.separator {
-			m.InsertSeparator(-1, false)
+			if gm, ok := m.(*menu); ok && one.caption != "" {
+				gm.insertCaptionedSeparator(-1, one.caption)
+			} else {
+				m.InsertSeparator(-1, false)
+			}
 		} else {
 			hasItem = true
 			m.InsertItem(-1, p.createMenuItem(m, i, one))
@@ -217,37 +282,142 @@ func (p *PopupMenu[T]) Click() {
 
 func (p *PopupMenu[T]) createMenuItem(m Menu, index int, entry *popupMenuItem[T]) MenuItem {
 	item := m.Factory().NewItem(PopupMenuTemporaryBaseID+index+1,
-		fmt.Sprintf("%v", entry.item), KeyBinding{}, func(_ MenuItem) bool {
+		fmt.Sprintf("%v", entry.item), entry.keyBinding, func(_ MenuItem) bool {
 			return entry.enabled
-		}, func(_ MenuItem) {
-			if p.ChoiceMadeCallback != nil {
+		}, func(mi MenuItem) {
+			if p.MultiSelect {
+				if p.toggleSelection(index) {
+					mi.SetCheckState(check.On)
+				} else {
+					mi.SetCheckState(check.Off)
+				}
+			} else if p.ChoiceMadeCallback != nil {
 				p.ChoiceMadeCallback(p, index, p.items[index].item)
 			}
 		})
 	if p.selection[index] {
 		item.SetCheckState(check.On)
 	}
+	if gi, ok := item.(*menuItem); ok {
+		gi.keepOpen = p.MultiSelect
+		gi.disabledReason = entry.disabledReason
+	}
 	return item
 }
 
+// toggleSelection toggles the selected state of the item at the index, returning true if it is now selected.
+func (p *PopupMenu[T]) toggleSelection(index int) bool {
+	selected := !p.selection[index]
+	if selected {
+		p.selection[index] = true
+	} else {
+		delete(p.selection, index)
+	}
+	p.MarkForRedraw()
+	if p.SelectionChangedCallback != nil {
+		p.SelectionChangedCallback(p)
+	}
+	return selected
+}
+
+func (p *PopupMenu[T]) multiSelectDone() {
+	if p.SelectionCallback != nil {
+		p.SelectionCallback(p, p.SelectedIndexes())
+	}
+}
+
 // AddItem appends one or more menu items to the end of the PopupMenu.
 func (p *PopupMenu[T]) AddItem(item ...T) {
-	for _, one := range item {
-		p.items = append(p.items, &popupMenuItem[T]{
-			item:    one,
-			enabled: true,
-		})
+	p.AddItems(item...)
+}
+
+// AddItemWithKey appends a menu item to the end of the PopupMenu that shows the given key binding when the menu is
+// displayed. While the PopupMenu is within a window, the key binding will also choose the item when pressed, regardless
+// of which panel has the keyboard focus.
+func (p *PopupMenu[T]) AddItemWithKey(item T, keyBinding KeyBinding) {
+	p.items = append(p.items, &popupMenuItem[T]{item: item, keyBinding: keyBinding, enabled: true})
+	p.itemsChanged()
+}
+
+// AddItems appends one or more menu items to the end of the PopupMenu, growing the underlying storage only once.
+// Returns the PopupMenu, to allow chaining.
+func (p *PopupMenu[T]) AddItems(items ...T) *PopupMenu[T] {
+	if len(items) != 0 {
+		p.items = slices.Grow(p.items, len(items))
+		entries := make([]popupMenuItem[T], len(items))
+		for i, one := range items {
+			entries[i].item = one
+			entries[i].enabled = true
+			p.items = append(p.items, &entries[i])
+		}
+		p.itemsChanged()
+	}
+	return p
+}
+
+// SetItems replaces the contents of the PopupMenu with the specified items. Any currently selected items that are still
+// present afterward will remain selected.
+func (p *PopupMenu[T]) SetItems(items []T) {
+	var before []T
+	for _, index := range p.SelectedIndexes() {
+		before = append(before, p.items[index].item)
+	}
+	p.items = nil
+	p.selection = make(map[int]bool)
+	p.AddItems(items...)
+	var after []T
+	for _, one := range before {
+		if index := p.IndexOfItem(one); index != -1 && !p.selection[index] {
+			p.selection[index] = true
+			after = append(after, one)
+		}
+	}
+	p.itemsChanged()
+	if len(before) != len(after) && p.SelectionChangedCallback != nil {
+		p.SelectionChangedCallback(p)
 	}
 }
 
 // AddDisabledItem appends a disabled menu item to the end of the PopupMenu.
 func (p *PopupMenu[T]) AddDisabledItem(item T) {
 	p.items = append(p.items, &popupMenuItem[T]{item: item})
+	p.itemsChanged()
+}
+
+// AddDisabledItemWithReason appends a disabled menu item to the end of the PopupMenu. The reason is shown as a tooltip
+// when the item is hovered over in the open menu, to explain why it is unavailable.
+func (p *PopupMenu[T]) AddDisabledItemWithReason(item T, reason string) {
+	p.items = append(p.items, &popupMenuItem[T]{item: item, disabledReason: reason})
+	p.itemsChanged()
+}
+
+// DisabledReason returns the explanation shown for the item at the specified index when it is disabled.
+func (p *PopupMenu[T]) DisabledReason(index int) string {
+	if index >= 0 && index < len(p.items) {
+		return p.items[index].disabledReason
+	}
+	return ""
+}
+
+// SetDisabledReason sets the explanation shown as a tooltip in the open menu for the item at the specified index
+// while it is disabled. Pass an empty string to remove it.
+func (p *PopupMenu[T]) SetDisabledReason(index int, reason string) {
+	if index >= 0 && index < len(p.items) && !p.items[index].separator {
+		p.items[index].disabledReason = reason
+	}
 }
 
 // AddSeparator adds a separator to the end of the PopupMenu.
 func (p *PopupMenu[T]) AddSeparator() {
 	p.items = append(p.items, &popupMenuItem[T]{separator: true})
+	p.itemsChanged()
+}
+
+// AddCaptionedSeparator adds a separator with a small leading caption to the end of the PopupMenu. Like a plain
+// separator, it cannot be selected.
+func (p *PopupMenu[T]) AddCaptionedSeparator(text string) {
+	p.items = append(p.items, &popupMenuItem[T]{separator: true, caption: text})
+	p.itemsChanged()
 }
 
 // IndexOfItem returns the index of the specified menu item. -1 will be returned if the menu item isn't present.
@@ -264,7 +434,7 @@ func (p *PopupMenu[T]) IndexOfItem(item T) int {
 func (p *PopupMenu[T]) RemoveAllItems() {
 	p.selection = make(map[int]bool)
 	p.items = nil
-	p.MarkForRedraw()
+	p.itemsChanged()
 }
 
 // RemoveItem from the PopupMenu.
@@ -301,7 +471,7 @@ func (p *PopupMenu[T]) RemoveItemAt(index int) {
 					p.selection[one-1] = true
 				}
 			}
-			p.MarkForRedraw()
+			p.itemsChanged()
 		}
 	}
 }
@@ -331,7 +501,8 @@ func (p *PopupMenu[T]) SetItemAt(index int, item T, enabled bool) {
 			one.item = item
 			one.enabled = enabled
 			one.separator = false
-			p.MarkForRedraw()
+			one.caption = ""
+			p.itemsChanged()
 		}
 	}
 }
@@ -426,9 +597,85 @@ func (p *PopupMenu[T]) DefaultKeyDown(keyCode KeyCode, mod Modifiers, _ bool) bo
 		p.Click()
 		return true
 	}
+	if p.HandleKeyBinding(keyCode, mod) {
+		return true
+	}
+	if mod&NonStickyModifiers != 0 {
+		return false
+	}
+	var index int
+	switch keyCode {
+	case KeyUp:
+		index = p.adjacentEnabledIndex(p.SelectedIndex(), -1)
+	case KeyDown:
+		index = p.adjacentEnabledIndex(p.SelectedIndex(), 1)
+	case KeyHome:
+		index = p.adjacentEnabledIndex(-1, 1)
+	case KeyEnd:
+		index = p.adjacentEnabledIndex(len(p.items), -1)
+	default:
+		return false
+	}
+	if index != -1 && index != p.SelectedIndex() && p.ChoiceMadeCallback != nil {
+		p.ChoiceMadeCallback(p, index, p.items[index].item)
+	}
+	return true
+}
+
+// HandleKeyBinding chooses the enabled item whose key binding matches the key code and modifiers, if any, returning
+// true if one was found. In MultiSelect mode, the item's selected state is toggled instead. This is called automatically
+// for key down events within the window the PopupMenu resides in.
+func (p *PopupMenu[T]) HandleKeyBinding(keyCode KeyCode, mod Modifiers) bool {
+	if !p.Enabled() {
+		return false
+	}
+	for i, one := range p.items {
+		if one.separator || one.keyBinding.KeyCode.ShouldOmit() || one.keyBinding.KeyCode != keyCode ||
+			one.keyBinding.Modifiers != mod {
+			continue
+		}
+		if !one.enabled {
+			return false
+		}
+		if p.MultiSelect {
+			p.toggleSelection(i)
+		} else if p.ChoiceMadeCallback != nil {
+			p.ChoiceMadeCallback(p, i, one.item)
+		}
+		return true
+	}
 	return false
 }
 
+// adjacentEnabledIndex returns the index of the first enabled, non-separator item found by stepping from the index in
+// the given direction, or -1 if there isn't one. Honors WrapKeyNavigation.
+func (p *PopupMenu[T]) adjacentEnabledIndex(from, direction int) int {
+	count := len(p.items)
+	if count == 0 {
+		return -1
+	}
+	if from < 0 && direction < 0 {
+		from = count
+	}
+	index := from
+	for range count {
+		index += direction
+		if index < 0 || index >= count {
+			if !p.WrapKeyNavigation {
+				return -1
+			}
+			index = (index + count) % count
+		}
+		if index == from {
+			return -1
+		}
+		if one := p.items[index]; one.enabled && !one.separator {
+			return index
+		}
+	}
+	return -1
+}
+
 // DefaultUpdateCursor provides the default cursor for popup menus.
 func (p *PopupMenu[T]) DefaultUpdateCursor(_ Point) *Cursor {
 	if !p.Enabled() {
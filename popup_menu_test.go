@@ -0,0 +1,38 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestPopupMenuMultiSelectToggle(t *testing.T) {
+	p := unison.NewPopupMenu[string]()
+	p.MultiSelect = true
+	p.AddItemWithKey("one", unison.KeyBinding{KeyCode: unison.KeyA})
+	p.AddItemWithKey("two", unison.KeyBinding{KeyCode: unison.KeyB})
+	p.AddItemWithKey("three", unison.KeyBinding{KeyCode: unison.KeyC})
+	choices := 0
+	p.ChoiceMadeCallback = func(_ *unison.PopupMenu[string], _ int, _ string) { choices++ }
+	changes := 0
+	p.SelectionChangedCallback = func(_ *unison.PopupMenu[string]) { changes++ }
+
+	check.True(t, p.HandleKeyBinding(unison.KeyA, 0))
+	check.True(t, p.HandleKeyBinding(unison.KeyC, 0))
+	check.Equal(t, []int{0, 2}, p.SelectedIndexes())
+
+	check.True(t, p.HandleKeyBinding(unison.KeyA, 0))
+	check.Equal(t, []int{2}, p.SelectedIndexes())
+	check.Equal(t, 3, changes)
+	check.Equal(t, 0, choices)
+}
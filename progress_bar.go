@@ -10,6 +10,7 @@
 package unison
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/richardwilkes/unison/enums/paintstyle"
@@ -21,6 +22,8 @@ var DefaultProgressBarTheme = ProgressBarTheme{
 	BackgroundInk:      ThemeSurface,
 	FillInk:            ThemeFocus,
 	EdgeInk:            ThemeSurfaceEdge,
+	Font:               LabelFont,
+	OnBackgroundInk:    ThemeOnSurface,
 	TickSpeed:          time.Second / 30,
 	FullTraversalSpeed: time.Second,
 	PreferredBarHeight: 8,
@@ -34,6 +37,8 @@ type ProgressBarTheme struct {
 	BackgroundInk      Ink
 	FillInk            Ink
 	EdgeInk            Ink
+	Font               Font
+	OnBackgroundInk    Ink
 	TickSpeed          time.Duration
 	FullTraversalSpeed time.Duration
 	PreferredBarHeight float32
@@ -49,6 +54,9 @@ type ProgressBar struct {
 	Panel
 	current float32
 	maximum float32
+	// ShowPercentage causes the percentage of progress to be drawn centered within the bar. This has no effect on
+	// indeterminate progress bars.
+	ShowPercentage bool
 }
 
 // NewProgressBar creates a new progress bar. A max of zero will create an indeterminate progress bar, i.e. one whose
@@ -106,12 +114,16 @@ func (p *ProgressBar) SetMaximum(value float32) {
 
 // DefaultSizes provides the default sizing.
 func (p *ProgressBar) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
+	height := p.PreferredBarHeight
+	if p.ShowPercentage {
+		height = max(height, p.Font.LineHeight())
+	}
 	minSize.Width = 80
-	minSize.Height = p.PreferredBarHeight
+	minSize.Height = height
 	prefSize.Width = 100
-	prefSize.Height = p.PreferredBarHeight
+	prefSize.Height = height
 	maxSize.Width = DefaultMaxSize
-	maxSize.Height = p.PreferredBarHeight
+	maxSize.Height = height
 	if border := p.Border(); border != nil {
 		insets := border.Insets().Size()
 		minSize = minSize.Add(insets)
@@ -128,7 +140,9 @@ func (p *ProgressBar) DefaultDraw(canvas *Canvas, _ Rect) {
 	meter.Width = 0
 	if p.maximum <= 0 {
 		meter.Width = p.IndeterminateWidth
-		if p.lastAnimationTime.IsZero() {
+		if ReducedMotion {
+			meter.X += (bounds.Width - p.IndeterminateWidth) / 2
+		} else if p.lastAnimationTime.IsZero() {
 			p.lastAnimationTime = time.Now()
 		} else {
 			maximum := bounds.Width - p.IndeterminateWidth
@@ -159,6 +173,22 @@ func (p *ProgressBar) DefaultDraw(canvas *Canvas, _ Rect) {
 		canvas.DrawRoundedRect(meter, p.CornerRadius, p.CornerRadius, paint)
 	}
 	if p.maximum == 0 {
-		InvokeTaskAfter(p.MarkForRedraw, p.TickSpeed)
+		if !ReducedMotion {
+			InvokeTaskAfter(p.animate, p.TickSpeed)
+		}
+	} else if p.ShowPercentage {
+		text := NewText(strconv.Itoa(int(100*p.current/p.maximum))+"%", &TextDecoration{
+			Font:            p.Font,
+			OnBackgroundInk: p.OnBackgroundInk,
+		})
+		bounds = p.ContentRect(false)
+		size := text.Extents()
+		text.Draw(canvas, bounds.X+(bounds.Width-size.Width)/2, bounds.Y+(bounds.Height-size.Height)/2+text.Baseline())
+	}
+}
+
+func (p *ProgressBar) animate() {
+	if w := p.Window(); w != nil && w.IsValid() {
+		p.MarkForRedraw()
 	}
 }
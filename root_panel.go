@@ -72,6 +72,9 @@ func (p *rootPanel) removeMenu(panel *menuPanel) {
 			panel.RemoveFromParent()
 			panel.menu.popupPanel = nil
 			p.MarkForRedraw()
+			if panel.menu.closedCallback != nil {
+				toolbox.Call(panel.menu.closedCallback)
+			}
 			break
 		}
 	}
@@ -0,0 +1,363 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
+	"github.com/richardwilkes/unison/enums/side"
+)
+
+// DefaultSegmentedControlTheme holds the default SegmentedControlTheme values for SegmentedControls. Modifying this
+// data will not alter existing SegmentedControls, but will alter any SegmentedControls created in the future.
+var DefaultSegmentedControlTheme = SegmentedControlTheme{
+	TextDecoration: TextDecoration{
+		Font:            SystemFont,
+		BackgroundInk:   ThemeAboveSurface,
+		OnBackgroundInk: ThemeOnAboveSurface,
+	},
+	EdgeInk:        ThemeSurfaceEdge,
+	SelectionInk:   ThemeFocus,
+	OnSelectionInk: ThemeOnFocus,
+	Gap:            3,
+	CornerRadius:   4,
+	HMargin:        8,
+	VMargin:        1,
+	Side:           side.Left,
+}
+
+// SegmentedControlTheme holds theming data for a SegmentedControl.
+type SegmentedControlTheme struct {
+	EdgeInk        Ink
+	SelectionInk   Ink
+	OnSelectionInk Ink
+	TextDecoration
+	Gap          float32
+	CornerRadius float32
+	HMargin      float32
+	VMargin      float32
+	Side         side.Enum
+}
+
+type segment struct {
+	text     *Text
+	drawable Drawable
+	selected bool
+}
+
+// SegmentedControl provides a row of joined segments, each of which may be selected. By default, only one segment may
+// be selected at a time, but setting MultiSelect to true permits any number of them to be selected.
+type SegmentedControl struct {
+	// SelectionChangedCallback is called whenever the set of selected segments changes.
+	SelectionChangedCallback func()
+	SegmentedControlTheme
+	segments []*segment
+	Panel
+	focusIndex    int
+	pressedIndex  int
+	MultiSelect   bool
+	pressedInside bool
+}
+
+// NewSegmentedControl creates a new, empty segmented control.
+func NewSegmentedControl() *SegmentedControl {
+	s := &SegmentedControl{
+		SegmentedControlTheme: DefaultSegmentedControlTheme,
+		pressedIndex:          -1,
+	}
+	s.Self = s
+	s.SetFocusable(true)
+	s.SetSizer(s.DefaultSizes)
+	s.DrawCallback = s.DefaultDraw
+	s.GainedFocusCallback = s.DefaultFocusGained
+	s.LostFocusCallback = s.MarkForRedraw
+	s.MouseDownCallback = s.DefaultMouseDown
+	s.MouseDragCallback = s.DefaultMouseDrag
+	s.MouseUpCallback = s.DefaultMouseUp
+	s.KeyDownCallback = s.DefaultKeyDown
+	s.UpdateCursorCallback = s.DefaultUpdateCursor
+	return s
+}
+
+// AddSegment adds a segment with the given title and/or drawable, returning its index. The theme's TextDecoration will
+// be used, so any changes you want to make to it should be done before calling this method.
+func (s *SegmentedControl) AddSegment(title string, drawable Drawable) int {
+	seg := &segment{drawable: drawable}
+	if title != "" {
+		seg.text = NewText(title, &s.TextDecoration)
+	}
+	s.segments = append(s.segments, seg)
+	s.NeedsLayout = true
+	s.MarkForRedraw()
+	return len(s.segments) - 1
+}
+
+// SegmentCount returns the number of segments.
+func (s *SegmentedControl) SegmentCount() int {
+	return len(s.segments)
+}
+
+// SelectedIndex returns the index of the first selected segment, or -1 if none are selected.
+func (s *SegmentedControl) SelectedIndex() int {
+	for i, seg := range s.segments {
+		if seg.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// SelectedIndexes returns the indexes of all selected segments.
+func (s *SegmentedControl) SelectedIndexes() []int {
+	var indexes []int
+	for i, seg := range s.segments {
+		if seg.selected {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// SetSelectedIndex makes the segment at the index the only selected segment. Pass -1 to deselect all segments.
+func (s *SegmentedControl) SetSelectedIndex(index int) {
+	changed := false
+	for i, seg := range s.segments {
+		if selected := i == index; seg.selected != selected {
+			seg.selected = selected
+			changed = true
+		}
+	}
+	if index >= 0 && index < len(s.segments) {
+		s.focusIndex = index
+	}
+	s.selectionChanged(changed)
+}
+
+// IsSegmentSelected returns true if the segment at the index is selected.
+func (s *SegmentedControl) IsSegmentSelected(index int) bool {
+	return index >= 0 && index < len(s.segments) && s.segments[index].selected
+}
+
+// SetSegmentSelected sets the selection state of the segment at the index. If MultiSelect is false, selecting a segment
+// will deselect all others.
+func (s *SegmentedControl) SetSegmentSelected(index int, selected bool) {
+	if index < 0 || index >= len(s.segments) {
+		return
+	}
+	if selected && !s.MultiSelect {
+		s.SetSelectedIndex(index)
+		return
+	}
+	seg := s.segments[index]
+	changed := seg.selected != selected
+	seg.selected = selected
+	s.selectionChanged(changed)
+}
+
+func (s *SegmentedControl) selectionChanged(changed bool) {
+	if changed {
+		s.MarkForRedraw()
+		if s.SelectionChangedCallback != nil {
+			s.SelectionChangedCallback()
+		}
+	}
+}
+
+func (s *SegmentedControl) isPressed(index int) bool {
+	return s.pressedInside && index == s.pressedIndex
+}
+
+// activate a segment as if the user clicked on it.
+func (s *SegmentedControl) activate(index int) {
+	if s.MultiSelect {
+		s.SetSegmentSelected(index, !s.IsSegmentSelected(index))
+	} else {
+		s.SetSelectedIndex(index)
+	}
+	s.focusIndex = index
+	s.MarkForRedraw()
+}
+
+// segmentContentSize returns the size needed for the largest segment's content, excluding margins.
+func (s *SegmentedControl) segmentContentSize() Size {
+	var size Size
+	for _, seg := range s.segments {
+		one, _ := LabelContentSizes(seg.text, seg.drawable, s.Font, s.Side, s.Gap)
+		size = size.Max(one)
+	}
+	return size
+}
+
+// DefaultSizes provides the default sizing.
+func (s *SegmentedControl) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
+	segSize := s.segmentContentSize()
+	segSize.Width += s.HMargin * 2
+	segSize.Height += s.VMargin * 2
+	count := float32(max(len(s.segments), 1))
+	prefSize = Size{Width: segSize.Width*count + count + 1, Height: segSize.Height + 2}
+	if border := s.Border(); border != nil {
+		prefSize = prefSize.Add(border.Insets().Size())
+	}
+	prefSize = prefSize.Ceil().ConstrainForHint(hint)
+	return prefSize, prefSize, MaxSize(prefSize)
+}
+
+// DefaultFocusGained provides the default focus gained handling.
+func (s *SegmentedControl) DefaultFocusGained() {
+	s.ScrollIntoView()
+	s.MarkForRedraw()
+}
+
+// segmentRect returns the rectangle of the segment at the index, excluding the outer edge and dividers.
+func (s *SegmentedControl) segmentRect(index int) Rect {
+	r := s.ContentRect(false).Inset(NewUniformInsets(1))
+	count := float32(len(s.segments))
+	width := (r.Width - (count - 1)) / count
+	r.X += float32(index) * (width + 1)
+	r.Width = width
+	return r
+}
+
+func (s *SegmentedControl) segmentAt(where Point) int {
+	for i := range s.segments {
+		r := s.segmentRect(i)
+		r.X -= 0.5
+		r.Width++
+		if where.In(r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultDraw provides the default drawing.
+func (s *SegmentedControl) DefaultDraw(canvas *Canvas, _ Rect) {
+	r := s.ContentRect(false)
+	canvas.DrawRoundedRect(r, s.CornerRadius, s.CornerRadius, s.BackgroundInk.Paint(canvas, r, paintstyle.Fill))
+	if len(s.segments) != 0 {
+		path := NewPath()
+		path.RoundedRect(r, s.CornerRadius, s.CornerRadius)
+		canvas.Save()
+		canvas.ClipPath(path, pathop.Intersect, true)
+		for i, seg := range s.segments {
+			if seg.selected || s.isPressed(i) {
+				sr := s.segmentRect(i)
+				canvas.DrawRect(sr, s.SelectionInk.Paint(canvas, sr, paintstyle.Fill))
+			}
+		}
+		canvas.Restore()
+		edge := s.EdgeInk.Paint(canvas, r, paintstyle.Stroke)
+		for i := 1; i < len(s.segments); i++ {
+			x := s.segmentRect(i).X - 0.5
+			canvas.DrawLine(x, r.Y, x, r.Bottom(), edge)
+		}
+		for i, seg := range s.segments {
+			fg := s.OnBackgroundInk
+			if seg.selected || s.isPressed(i) {
+				fg = s.OnSelectionInk
+			}
+			sr := s.segmentRect(i).Inset(NewSymmetricInsets(s.HMargin, s.VMargin))
+			saved := seg.text.AdjustDecorations(func(d *TextDecoration) {
+				d.BackgroundInk = nil
+				d.OnBackgroundInk = fg
+			})
+			DrawLabel(canvas, sr, align.Middle, align.Middle, s.Font, seg.text, fg, nil, seg.drawable, s.Side, s.Gap,
+				!s.Enabled())
+			seg.text.RestoreDecorations(saved)
+		}
+		// The focused segment is always marked, as a focus ring drawn around the control can't indicate which of its
+		// segments has the focus
+		if s.Focused() && s.focusIndex >= 0 && s.focusIndex < len(s.segments) {
+			ink := s.SelectionInk
+			if s.segments[s.focusIndex].selected || s.isPressed(s.focusIndex) {
+				ink = s.OnSelectionInk
+			}
+			DrawFocusRect(canvas, s.segmentRect(s.focusIndex), ink)
+		}
+	}
+	DrawRoundedRectBase(canvas, r, s.CornerRadius, 1, Transparent, s.EdgeInk)
+}
+
+// DefaultMouseDown provides the default mouse down handling.
+func (s *SegmentedControl) DefaultMouseDown(where Point, button, _ int, _ Modifiers) bool {
+	if button != ButtonLeft || !s.Enabled() {
+		return false
+	}
+	s.pressedIndex = s.segmentAt(where)
+	s.pressedInside = s.pressedIndex >= 0
+	s.MarkForRedraw()
+	return true
+}
+
+// DefaultMouseDrag provides the default mouse drag handling.
+func (s *SegmentedControl) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
+	if s.pressedIndex >= 0 {
+		if inside := s.segmentAt(where) == s.pressedIndex; inside != s.pressedInside {
+			s.pressedInside = inside
+			s.MarkForRedraw()
+		}
+	}
+	return true
+}
+
+// DefaultMouseUp provides the default mouse up handling.
+func (s *SegmentedControl) DefaultMouseUp(where Point, _ int, _ Modifiers) bool {
+	index := s.pressedIndex
+	s.pressedIndex = -1
+	s.pressedInside = false
+	s.MarkForRedraw()
+	if index >= 0 && s.segmentAt(where) == index {
+		s.activate(index)
+	}
+	return true
+}
+
+// DefaultKeyDown provides the default key down handling.
+func (s *SegmentedControl) DefaultKeyDown(keyCode KeyCode, mod Modifiers, _ bool) bool {
+	if len(s.segments) == 0 {
+		return false
+	}
+	if IsControlAction(keyCode, mod) {
+		s.activate(s.focusIndex)
+		return true
+	}
+	index := s.focusIndex
+	switch keyCode {
+	case KeyLeft, KeyUp:
+		index--
+	case KeyRight, KeyDown:
+		index++
+	case KeyHome:
+		index = 0
+	case KeyEnd:
+		index = len(s.segments) - 1
+	default:
+		return false
+	}
+	index = max(min(index, len(s.segments)-1), 0)
+	if index != s.focusIndex {
+		if s.MultiSelect {
+			s.focusIndex = index
+			s.MarkForRedraw()
+		} else {
+			s.activate(index)
+		}
+	}
+	return true
+}
+
+// DefaultUpdateCursor provides the default cursor for segmented controls.
+func (s *SegmentedControl) DefaultUpdateCursor(_ Point) *Cursor {
+	if !s.Enabled() {
+		return ArrowCursor()
+	}
+	return PointingCursor()
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+// DefaultSliderTheme holds the default SliderTheme values for Sliders. Modifying this data will not alter existing
+// Sliders, but will alter any Sliders created in the future.
+var DefaultSliderTheme = SliderTheme{
+	TrackInk:       ThemeBelowSurface,
+	FillInk:        ThemeFocus,
+	ThumbInk:       ThemeAboveSurface,
+	EdgeInk:        ThemeSurfaceEdge,
+	SelectionInk:   ThemeFocus,
+	TickInk:        ThemeSurfaceEdge,
+	TrackThickness: 4,
+	ThumbSize:      14,
+	TickLength:     4,
+	MinimumLength:  100,
+}
+
+// SliderTheme holds theming data for a Slider.
+type SliderTheme struct {
+	TrackInk       Ink
+	FillInk        Ink
+	ThumbInk       Ink
+	EdgeInk        Ink
+	SelectionInk   Ink
+	TickInk        Ink
+	TrackThickness float32
+	ThumbSize      float32
+	TickLength     float32
+	MinimumLength  float32
+}
+
+// Slider provides a control for selecting a value, or a range of values when RangeMode is true, from within a minimum
+// and maximum by dragging a thumb along a track.
+type Slider struct {
+	// ValueChangedCallback is called whenever the value, or range of values, changes.
+	ValueChangedCallback func()
+	SliderTheme
+	Panel
+	activeThumb int
+	// Step, if greater than 0, causes values to be snapped to multiples of it from the minimum. It is also the amount
+	// the arrow keys adjust the value by. If 0, the arrow keys will adjust by 1/100th of the range.
+	Step float32
+	// PageStep is the amount the page up and page down keys adjust the value by. If 0, 1/10th of the range is used.
+	PageStep float32
+	// TickInterval, if greater than 0, causes tick marks to be drawn at multiples of it from the minimum.
+	TickInterval float32
+	minimum      float32
+	maximum      float32
+	low          float32
+	high         float32
+	// Vertical causes the slider to be oriented vertically, with the minimum at the bottom.
+	Vertical bool
+	// RangeMode causes the slider to have two thumbs, allowing a range of values to be selected.
+	RangeMode bool
+	dragging  bool
+}
+
+// NewSlider creates a new slider for values between minimum and maximum.
+func NewSlider(minimum, maximum float32) *Slider {
+	s := &Slider{SliderTheme: DefaultSliderTheme}
+	s.Self = s
+	s.SetFocusable(true)
+	s.SetSizer(s.DefaultSizes)
+	s.DrawCallback = s.DefaultDraw
+	s.GainedFocusCallback = s.DefaultFocusGained
+	s.LostFocusCallback = s.MarkForRedraw
+	s.MouseDownCallback = s.DefaultMouseDown
+	s.MouseDragCallback = s.DefaultMouseDrag
+	s.MouseUpCallback = s.DefaultMouseUp
+	s.KeyDownCallback = s.DefaultKeyDown
+	s.SetRange(minimum, maximum)
+	return s
+}
+
+// Minimum returns the minimum value.
+func (s *Slider) Minimum() float32 {
+	return s.minimum
+}
+
+// Maximum returns the maximum value.
+func (s *Slider) Maximum() float32 {
+	return s.maximum
+}
+
+// SetRange sets the minimum and maximum values. The current values will be constrained to fit.
+func (s *Slider) SetRange(minimum, maximum float32) {
+	if maximum < minimum {
+		minimum, maximum = maximum, minimum
+	}
+	s.minimum = minimum
+	s.maximum = maximum
+	s.SetRangeValues(s.low, s.high)
+	s.MarkForRedraw()
+}
+
+// Value returns the current value. In range mode, this is the upper value of the range.
+func (s *Slider) Value() float32 {
+	if s.RangeMode {
+		return s.high
+	}
+	return s.low
+}
+
+// SetValue sets the current value. In range mode, this sets the upper value of the range.
+func (s *Slider) SetValue(value float32) {
+	if s.RangeMode {
+		s.SetRangeValues(s.low, value)
+	} else {
+		s.SetRangeValues(value, value)
+	}
+}
+
+// RangeValues returns the lower and upper values of the selected range. When not in range mode, both will be the same.
+func (s *Slider) RangeValues() (low, high float32) {
+	if s.RangeMode {
+		return s.low, s.high
+	}
+	return s.low, s.low
+}
+
+// SetRangeValues sets the lower and upper values of the selected range. When not in range mode, only the low value is
+// used.
+func (s *Slider) SetRangeValues(low, high float32) {
+	low = s.constrain(low)
+	if s.RangeMode {
+		high = max(s.constrain(high), low)
+	} else {
+		high = low
+	}
+	if s.low != low || s.high != high {
+		s.low = low
+		s.high = high
+		s.MarkForRedraw()
+		if s.ValueChangedCallback != nil {
+			s.ValueChangedCallback()
+		}
+	}
+}
+
+func (s *Slider) constrain(value float32) float32 {
+	if s.Step > 0 {
+		value = s.minimum + xmath.Round((value-s.minimum)/s.Step)*s.Step
+	}
+	return max(min(value, s.maximum), s.minimum)
+}
+
+// DefaultFocusGained provides the default focus gained handling.
+func (s *Slider) DefaultFocusGained() {
+	s.ScrollIntoView()
+	s.MarkForRedraw()
+}
+
+// DefaultSizes provides the default sizing.
+func (s *Slider) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
+	thickness := max(s.ThumbSize, s.TrackThickness)
+	if s.TickInterval > 0 {
+		thickness += s.TickLength * 2
+	}
+	length := max(s.MinimumLength, s.ThumbSize*2)
+	if s.Vertical {
+		minSize = Size{Width: thickness, Height: length}
+		maxSize = Size{Width: thickness, Height: DefaultMaxSize}
+	} else {
+		minSize = Size{Width: length, Height: thickness}
+		maxSize = Size{Width: DefaultMaxSize, Height: thickness}
+	}
+	if border := s.Border(); border != nil {
+		insets := border.Insets().Size()
+		minSize = minSize.Add(insets)
+		maxSize = maxSize.Add(insets)
+	}
+	minSize = minSize.Ceil()
+	return minSize, minSize.ConstrainForHint(hint), maxSize.Ceil()
+}
+
+// trackBounds returns the start and length of the area along the slider that the center of a thumb may occupy.
+func (s *Slider) trackBounds() (start, length float32) {
+	r := s.ContentRect(false)
+	half := s.ThumbSize / 2
+	if s.Vertical {
+		return r.Y + half, max(r.Height-s.ThumbSize, 0)
+	}
+	return r.X + half, max(r.Width-s.ThumbSize, 0)
+}
+
+func (s *Slider) positionForValue(value float32) float32 {
+	start, length := s.trackBounds()
+	var fraction float32
+	if s.maximum > s.minimum {
+		fraction = (value - s.minimum) / (s.maximum - s.minimum)
+	}
+	if s.Vertical {
+		fraction = 1 - fraction
+	}
+	return start + length*fraction
+}
+
+func (s *Slider) valueForPosition(where Point) float32 {
+	start, length := s.trackBounds()
+	pos := where.X
+	if s.Vertical {
+		pos = where.Y
+	}
+	var fraction float32
+	if length > 0 {
+		fraction = max(min((pos-start)/length, 1), 0)
+	}
+	if s.Vertical {
+		fraction = 1 - fraction
+	}
+	return s.minimum + fraction*(s.maximum-s.minimum)
+}
+
+func (s *Slider) thumbRect(value float32) Rect {
+	r := s.ContentRect(false)
+	pos := s.positionForValue(value) - s.ThumbSize/2
+	if s.Vertical {
+		return NewRect(r.X+(r.Width-s.ThumbSize)/2, pos, s.ThumbSize, s.ThumbSize)
+	}
+	return NewRect(pos, r.Y+(r.Height-s.ThumbSize)/2, s.ThumbSize, s.ThumbSize)
+}
+
+// DefaultDraw provides the default drawing.
+func (s *Slider) DefaultDraw(canvas *Canvas, _ Rect) {
+	r := s.ContentRect(false)
+	start, length := s.trackBounds()
+	var track Rect
+	if s.Vertical {
+		track = NewRect(r.X+(r.Width-s.TrackThickness)/2, start, s.TrackThickness, length)
+	} else {
+		track = NewRect(start, r.Y+(r.Height-s.TrackThickness)/2, length, s.TrackThickness)
+	}
+	radius := s.TrackThickness / 2
+	DrawRoundedRectBase(canvas, track, radius, 1, s.TrackInk, s.EdgeInk)
+	// Ticks are skipped when there would be more than can be meaningfully distinguished. The count is checked before
+	// converting it to an int, since it may be too large to be represented by one.
+	const maxTicks = 1000
+	if count := (s.maximum - s.minimum) / s.TickInterval; s.TickInterval > 0 && count > 0 && count <= maxTicks {
+		paint := s.TickInk.Paint(canvas, r, paintstyle.Stroke)
+		for i := range int(count) + 1 {
+			pos := s.positionForValue(s.minimum + float32(i)*s.TickInterval)
+			if s.Vertical {
+				canvas.DrawLine(r.X, pos, r.X+s.TickLength, pos, paint)
+				canvas.DrawLine(r.Right()-s.TickLength, pos, r.Right(), pos, paint)
+			} else {
+				canvas.DrawLine(pos, r.Y, pos, r.Y+s.TickLength, paint)
+				canvas.DrawLine(pos, r.Bottom()-s.TickLength, pos, r.Bottom(), paint)
+			}
+		}
+	}
+	fillStart := s.minimum
+	if s.RangeMode {
+		fillStart = s.low
+	}
+	p1 := s.positionForValue(fillStart)
+	p2 := s.positionForValue(s.Value())
+	fill := track
+	if s.Vertical {
+		fill.Y = min(p1, p2)
+		fill.Height = xmath.Abs(p2 - p1)
+	} else {
+		fill.X = min(p1, p2)
+		fill.Width = xmath.Abs(p2 - p1)
+	}
+	if fill.Width > 0 && fill.Height > 0 {
+		canvas.DrawRoundedRect(fill, radius, radius, s.FillInk.Paint(canvas, fill, paintstyle.Fill))
+	}
+	if s.RangeMode {
+		s.drawThumb(canvas, s.low, 0)
+		s.drawThumb(canvas, s.high, 1)
+	} else {
+		s.drawThumb(canvas, s.low, 0)
+	}
+}
+
+func (s *Slider) drawThumb(canvas *Canvas, value float32, thumb int) {
+	edge := s.EdgeInk
+	thickness := float32(1)
+	if s.Focused() && thumb == s.activeThumb {
+		edge = s.SelectionInk
+		thickness++
+	}
+	var bg Ink = s.ThumbInk
+	if !s.Enabled() {
+		bg = &ColorFilteredInk{OriginalInk: bg, ColorFilter: Grayscale30Filter()}
+	}
+	DrawEllipseBase(canvas, s.thumbRect(value), thickness, bg, edge)
+}
+
+// DefaultMouseDown provides the default mouse down handling.
+func (s *Slider) DefaultMouseDown(where Point, button, _ int, _ Modifiers) bool {
+	if button != ButtonLeft || !s.Enabled() {
+		return false
+	}
+	s.RequestFocus()
+	value := s.valueForPosition(where)
+	s.activeThumb = 0
+	if s.RangeMode && (value > s.high || xmath.Abs(value-s.high) < xmath.Abs(value-s.low)) {
+		s.activeThumb = 1
+	}
+	s.dragging = true
+	s.adjustActiveThumb(value)
+	return true
+}
+
+// DefaultMouseDrag provides the default mouse drag handling.
+func (s *Slider) DefaultMouseDrag(where Point, _ int, _ Modifiers) bool {
+	if s.dragging {
+		s.adjustActiveThumb(s.valueForPosition(where))
+	}
+	return true
+}
+
+// DefaultMouseUp provides the default mouse up handling.
+func (s *Slider) DefaultMouseUp(_ Point, _ int, _ Modifiers) bool {
+	s.dragging = false
+	return true
+}
+
+func (s *Slider) adjustActiveThumb(value float32) {
+	switch {
+	case !s.RangeMode:
+		s.SetRangeValues(value, value)
+	case s.activeThumb == 0:
+		s.SetRangeValues(min(value, s.high), s.high)
+	default:
+		s.SetRangeValues(s.low, max(value, s.low))
+	}
+}
+
+func (s *Slider) activeValue() float32 {
+	if s.RangeMode && s.activeThumb == 1 {
+		return s.high
+	}
+	return s.low
+}
+
+// DefaultKeyDown provides the default key down handling.
+func (s *Slider) DefaultKeyDown(keyCode KeyCode, mod Modifiers, _ bool) bool {
+	step := s.Step
+	if step <= 0 {
+		step = (s.maximum - s.minimum) / 100
+	}
+	pageStep := s.PageStep
+	if pageStep <= 0 {
+		pageStep = max((s.maximum-s.minimum)/10, step)
+	}
+	value := s.activeValue()
+	switch keyCode {
+	case KeyLeft, KeyDown:
+		value -= step
+	case KeyRight, KeyUp:
+		value += step
+	case KeyPageDown:
+		value -= pageStep
+	case KeyPageUp:
+		value += pageStep
+	case KeyHome:
+		value = s.minimum
+	case KeyEnd:
+		value = s.maximum
+	case KeyTab:
+		if s.RangeMode && mod&NonStickyModifiers == 0 && s.activeThumb == 0 {
+			s.activeThumb = 1
+			s.MarkForRedraw()
+			return true
+		}
+		if s.RangeMode && mod == ShiftModifier && s.activeThumb == 1 {
+			s.activeThumb = 0
+			s.MarkForRedraw()
+			return true
+		}
+		return false
+	default:
+		return false
+	}
+	s.adjustActiveThumb(value)
+	return true
+}
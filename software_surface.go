@@ -0,0 +1,74 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison/internal/skia"
+)
+
+// SoftwareSurface is an off-screen drawing surface that is rasterized entirely on the CPU. Its output does not depend
+// on the graphics hardware in use, making it suitable for producing reference images for comparison in tests.
+type SoftwareSurface struct {
+	surface *surface
+	canvas  *Canvas
+}
+
+// NewSoftwareSurface creates a new SoftwareSurface with the given logical size. The scale determines the number of
+// pixels per logical unit.
+func NewSoftwareSurface(size Size, scale float32) (*SoftwareSurface, error) {
+	if scale <= 0 {
+		return nil, errs.New("invalid scale")
+	}
+	size.Width = xmath.Ceil(size.Width*scale) / scale
+	size.Height = xmath.Ceil(size.Height*scale) / scale
+	if size.Width < 1 || size.Height < 1 {
+		return nil, errs.New("invalid size")
+	}
+	s := &surface{software: true}
+	c, err := s.prepareCanvas(size, Rect{Size: size}, scale, scale)
+	if err != nil {
+		return nil, err
+	}
+	return &SoftwareSurface{
+		surface: s,
+		canvas:  c,
+	}, nil
+}
+
+// Canvas returns the canvas for drawing into the surface.
+func (s *SoftwareSurface) Canvas() *Canvas {
+	return s.canvas
+}
+
+// SnapshotImage returns an image of the current contents of the surface. The image's scale is set such that its
+// logical size matches that of the surface.
+func (s *SoftwareSurface) SnapshotImage() (*Image, error) {
+	return s.snapshotImage(1 / s.surface.scaleX)
+}
+
+func (s *SoftwareSurface) snapshotImage(scale float32) (*Image, error) {
+	s.canvas.Flush()
+	pixels, width, height, err := s.surface.pixels(skia.AlphaTypeUnPreMul)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageFromPixels(width, height, pixels, scale)
+}
+
+// Dispose releases the resources held by the surface. It may not be used after this call.
+func (s *SoftwareSurface) Dispose() {
+	if s.surface != nil {
+		s.surface.dispose()
+		s.surface = nil
+		s.canvas = nil
+	}
+}
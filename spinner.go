@@ -0,0 +1,144 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"time"
+
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/strokecap"
+)
+
+// DefaultSpinnerTheme holds the default SpinnerTheme values for Spinners. Modifying this data will not alter existing
+// Spinners, but will alter any Spinners created in the future.
+var DefaultSpinnerTheme = SpinnerTheme{
+	Font:            LabelFont,
+	TrackInk:        ThemeSurfaceEdge,
+	ArcInk:          ThemeFocus,
+	TickSpeed:       time.Second / 30,
+	RevolutionSpeed: time.Second,
+	StrokeWidth:     2,
+	ArcSweep:        90,
+}
+
+// SpinnerTheme holds theming data for a Spinner.
+type SpinnerTheme struct {
+	Font            Font
+	TrackInk        Ink
+	ArcInk          Ink
+	TickSpeed       time.Duration
+	RevolutionSpeed time.Duration
+	StrokeWidth     float32
+	ArcSweep        float32
+}
+
+// Spinner provides an activity indicator for waits of indeterminate length, drawn as an arc rotating around a track.
+type Spinner struct {
+	startTime time.Time
+	SpinnerTheme
+	Panel
+	generation int
+	running    bool
+}
+
+// NewSpinner creates a new spinner. The spinner is not started automatically.
+func NewSpinner() *Spinner {
+	s := &Spinner{SpinnerTheme: DefaultSpinnerTheme}
+	s.Self = s
+	s.SetSizer(s.DefaultSizes)
+	s.DrawCallback = s.DefaultDraw
+	return s
+}
+
+// Running returns true if the spinner is currently animating.
+func (s *Spinner) Running() bool {
+	return s.running
+}
+
+// Start the animation. Since the animation stops on its own if the spinner is not within a valid window, this should be
+// called once the spinner has been placed into a window. Does nothing if the spinner is already running.
+func (s *Spinner) Start() {
+	if !s.running {
+		s.running = true
+		s.generation++
+		s.startTime = time.Now()
+		if !ReducedMotion {
+			s.scheduleTick()
+		}
+		s.MarkForRedraw()
+	}
+}
+
+// Stop the animation.
+func (s *Spinner) Stop() {
+	if s.running {
+		s.running = false
+		s.generation++
+		s.MarkForRedraw()
+	}
+}
+
+func (s *Spinner) scheduleTick() {
+	generation := s.generation
+	InvokeTaskAfter(func() { s.tick(generation) }, s.TickSpeed)
+}
+
+func (s *Spinner) tick(generation int) {
+	if !s.running || generation != s.generation {
+		return
+	}
+	if w := s.Window(); w == nil || !w.IsValid() {
+		s.running = false
+		return
+	}
+	s.MarkForRedraw()
+	if !ReducedMotion {
+		s.scheduleTick()
+	}
+}
+
+// DefaultSizes provides the default sizing.
+func (s *Spinner) DefaultSizes(hint Size) (minSize, prefSize, maxSize Size) {
+	size := s.Font.LineHeight()
+	prefSize = Size{Width: size, Height: size}
+	if border := s.Border(); border != nil {
+		prefSize = prefSize.Add(border.Insets().Size())
+	}
+	prefSize = prefSize.Ceil().ConstrainForHint(hint)
+	return prefSize, prefSize, prefSize
+}
+
+// DefaultDraw provides the default drawing.
+func (s *Spinner) DefaultDraw(canvas *Canvas, _ Rect) {
+	r := s.ContentRect(false)
+	size := min(r.Width, r.Height) - s.StrokeWidth
+	if size <= 0 {
+		return
+	}
+	r.X += (r.Width - size) / 2
+	r.Y += (r.Height - size) / 2
+	r.Width = size
+	r.Height = size
+	paint := s.TrackInk.Paint(canvas, r, paintstyle.Stroke)
+	paint.SetStrokeWidth(s.StrokeWidth)
+	canvas.DrawOval(r, paint)
+	if !s.running {
+		return
+	}
+	var angle float32
+	if s.RevolutionSpeed > 0 && !ReducedMotion {
+		elapsed := time.Since(s.startTime) % s.RevolutionSpeed
+		angle = 360 * float32(elapsed) / float32(s.RevolutionSpeed)
+	}
+	paint = s.ArcInk.Paint(canvas, r, paintstyle.Stroke)
+	paint.SetStrokeWidth(s.StrokeWidth)
+	paint.SetStrokeCap(strokecap.Round)
+	canvas.DrawArc(r, angle-90, s.ArcSweep, paint, false)
+}
@@ -12,6 +12,7 @@ package unison
 import (
 	"github.com/go-gl/gl/v3.2-core/gl"
 	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/internal/skia"
 )
 
@@ -28,6 +29,11 @@ type surface struct {
 	size    Size
 	scaleX  float32
 	scaleY  float32
+	// software causes the surface to be rasterized on the CPU, with the result copied into the window's framebuffer by
+	// present().
+	software bool
+	texture  uint32
+	fbo      uint32
 }
 
 func (s *surface) prepareCanvas(size Size, _ Rect, scaleX, scaleY float32) (*Canvas, error) {
@@ -37,6 +43,17 @@ func (s *surface) prepareCanvas(size Size, _ Rect, scaleX, scaleY float32) (*Can
 		s.scaleX = scaleX
 		s.scaleY = scaleY
 	}
+	if s.surface == nil && s.software {
+		if s.surface = skia.SurfaceMakeRasterN32PreMul(&skia.ImageInfo{
+			Colorspace: skiaColorspace,
+			Width:      int32(xmath.Round(size.Width * scaleX)),
+			Height:     int32(xmath.Round(size.Height * scaleY)),
+			ColorType:  skia.ColorTypeRGBA8888,
+			AlphaType:  skia.AlphaTypePreMul,
+		}, defaultSurfaceProps()); s.surface == nil {
+			return nil, errs.New("unable to create software rendering surface")
+		}
+	}
 	if s.surface == nil {
 		if s.context == nil {
 			s.context = skia.ContextMakeGL(defaultSkiaGL())
@@ -59,18 +76,71 @@ func (s *surface) prepareCanvas(size Size, _ Rect, scaleX, scaleY float32) (*Can
 		canvas:  skia.SurfaceGetCanvas(s.surface),
 		surface: s,
 	}
-	skia.ContextReset(s.context)
+	if s.context != nil {
+		skia.ContextReset(s.context)
+	}
 	c.RestoreToCount(1)
 	c.SetMatrix(NewScaleMatrix(scaleX, scaleY))
 	return c, nil
 }
 
 func (s *surface) flush(syncCPU bool) {
-	if s != nil && s.surface != nil {
+	if s != nil && s.surface != nil && s.context != nil {
 		skia.ContextFlushAndSubmit(s.context, syncCPU)
 	}
 }
 
+// pixels reads back the contents of a CPU-rasterized surface as RGBA data.
+func (s *surface) pixels(alphaType skia.AlphaType) (pixels []byte, width, height int, err error) {
+	img := skia.SurfaceMakeImageSnapshot(s.surface)
+	if img == nil {
+		return nil, 0, 0, errs.New("unable to snapshot surface")
+	}
+	defer skia.ImageUnref(img)
+	width = skia.ImageGetWidth(img)
+	height = skia.ImageGetHeight(img)
+	pixels = make([]byte, width*height*4)
+	if !skia.ImageReadPixels(img, &skia.ImageInfo{
+		Colorspace: skiaColorspace,
+		Width:      int32(width),
+		Height:     int32(height),
+		ColorType:  skia.ColorTypeRGBA8888,
+		AlphaType:  alphaType,
+	}, pixels, width*4, 0, 0, skia.ImageCachingHintDisallow) {
+		return nil, 0, 0, errs.New("unable to read raw pixels from surface")
+	}
+	return pixels, width, height, nil
+}
+
+// present copies the contents of a CPU-rasterized surface into the current GL framebuffer. Does nothing for surfaces
+// that are rendered directly into the framebuffer.
+func (s *surface) present() error {
+	if !s.software || s.surface == nil {
+		return nil
+	}
+	pixels, width, height, err := s.pixels(skia.AlphaTypePreMul)
+	if err != nil {
+		return err
+	}
+	if s.texture == 0 {
+		gl.GenTextures(1, &s.texture)
+		gl.GenFramebuffers(1, &s.fbo)
+	}
+	var drawFBO int32
+	gl.GetIntegerv(gl.DRAW_FRAMEBUFFER_BINDING, &drawFBO)
+	gl.BindTexture(gl.TEXTURE_2D, s.texture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, int32(width), int32(height), 0, gl.RGBA, gl.UNSIGNED_BYTE,
+		gl.Ptr(pixels))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, s.fbo)
+	gl.FramebufferTexture2D(gl.READ_FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, s.texture, 0)
+	// The raster surface has its origin at the top-left, while GL's is at the bottom-left, so flip vertically
+	gl.BlitFramebuffer(0, 0, int32(width), int32(height), 0, int32(height), int32(width), 0, gl.COLOR_BUFFER_BIT,
+		gl.NEAREST)
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, uint32(drawFBO))
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	return nil
+}
+
 func (s *surface) partialDispose() {
 	if s.surface != nil {
 		skia.SurfaceUnref(s.surface)
@@ -84,6 +154,12 @@ func (s *surface) partialDispose() {
 
 func (s *surface) dispose() {
 	s.partialDispose()
+	if s.texture != 0 {
+		gl.DeleteFramebuffers(1, &s.fbo)
+		gl.DeleteTextures(1, &s.texture)
+		s.fbo = 0
+		s.texture = 0
+	}
 	if s.context != nil {
 		releaseImagesForContext(s.context)
 		skia.ContextAbandonContext(s.context)
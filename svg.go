@@ -10,88 +10,288 @@
 package unison
 
 import (
+	"context"
 	_ "embed"
+	"encoding/base64"
 	"encoding/xml"
 	"io"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/fatal"
+	"github.com/richardwilkes/toolbox/xio"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/svgdrawmode"
 )
 
 var _ Drawable = &DrawableSVG{}
 
-// Pre-defined SVG images used by Unison.
+// Pre-defined SVG images used by Unison. Each is also available through an accessor function, such as
+// LoadWindowMaximizeSVG(), that reports any error encountered while parsing it. Should one fail to parse, the error is
+// logged and the variable holds an empty SVG instead.
 var (
 	//go:embed resources/images/broken_image.svg
-	brokenImageSVG string
-	BrokenImageSVG = MustSVGFromContentString(brokenImageSVG)
+	brokenImageSVGContent string
+	loadBrokenImageSVG    = builtinSVG("broken_image.svg", brokenImageSVGContent)
+	BrokenImageSVG        = builtinSVGOrEmpty(loadBrokenImageSVG)
 
 	//go:embed resources/images/circled_chevron_right.svg
-	circledChevronRightSVG string
-	CircledChevronRightSVG = MustSVGFromContentString(circledChevronRightSVG)
+	circledChevronRightSVGContent string
+	loadCircledChevronRightSVG    = builtinSVG("circled_chevron_right.svg", circledChevronRightSVGContent)
+	CircledChevronRightSVG        = builtinSVGOrEmpty(loadCircledChevronRightSVG)
 
 	//go:embed resources/images/circled_exclamation.svg
-	circledExclamationSVG string
-	CircledExclamationSVG = MustSVGFromContentString(circledExclamationSVG)
+	circledExclamationSVGContent string
+	loadCircledExclamationSVG    = builtinSVG("circled_exclamation.svg", circledExclamationSVGContent)
+	CircledExclamationSVG        = builtinSVGOrEmpty(loadCircledExclamationSVG)
 
 	//go:embed resources/images/circled_question.svg
-	circledQuestionSVG string
-	CircledQuestionSVG = MustSVGFromContentString(circledQuestionSVG)
+	circledQuestionSVGContent string
+	loadCircledQuestionSVG    = builtinSVG("circled_question.svg", circledQuestionSVGContent)
+	CircledQuestionSVG        = builtinSVGOrEmpty(loadCircledQuestionSVG)
 
 	//go:embed resources/images/checkmark.svg
-	checkmarkSVG string
-	CheckmarkSVG = MustSVGFromContentString(checkmarkSVG)
+	checkmarkSVGContent string
+	loadCheckmarkSVG    = builtinSVG("checkmark.svg", checkmarkSVGContent)
+	CheckmarkSVG        = builtinSVGOrEmpty(loadCheckmarkSVG)
 
 	//go:embed resources/images/chevron_right.svg
-	chevronRightSVG string
-	ChevronRightSVG = MustSVGFromContentString(chevronRightSVG)
+	chevronRightSVGContent string
+	loadChevronRightSVG    = builtinSVG("chevron_right.svg", chevronRightSVGContent)
+	ChevronRightSVG        = builtinSVGOrEmpty(loadChevronRightSVG)
 
 	//go:embed resources/images/circled_x.svg
-	circledXSVG string
-	CircledXSVG = MustSVGFromContentString(circledXSVG)
+	circledXSVGContent string
+	loadCircledXSVG    = builtinSVG("circled_x.svg", circledXSVGContent)
+	CircledXSVG        = builtinSVGOrEmpty(loadCircledXSVG)
 
 	//go:embed resources/images/dash.svg
-	dashSVG string
-	DashSVG = MustSVGFromContentString(dashSVG)
+	dashSVGContent string
+	loadDashSVG    = builtinSVG("dash.svg", dashSVGContent)
+	DashSVG        = builtinSVGOrEmpty(loadDashSVG)
 
 	//go:embed resources/images/document.svg
-	documentSVG string
-	DocumentSVG = MustSVGFromContentString(documentSVG)
+	documentSVGContent string
+	loadDocumentSVG    = builtinSVG("document.svg", documentSVGContent)
+	DocumentSVG        = builtinSVGOrEmpty(loadDocumentSVG)
 
 	//go:embed resources/images/sort_ascending.svg
-	sortAscendingSVG string
-	SortAscendingSVG = MustSVGFromContentString(sortAscendingSVG)
+	sortAscendingSVGContent string
+	loadSortAscendingSVG    = builtinSVG("sort_ascending.svg", sortAscendingSVGContent)
+	SortAscendingSVG        = builtinSVGOrEmpty(loadSortAscendingSVG)
 
 	//go:embed resources/images/sort_descending.svg
-	sortDescendingSVG string
-	SortDescendingSVG = MustSVGFromContentString(sortDescendingSVG)
+	sortDescendingSVGContent string
+	loadSortDescendingSVG    = builtinSVG("sort_descending.svg", sortDescendingSVGContent)
+	SortDescendingSVG        = builtinSVGOrEmpty(loadSortDescendingSVG)
 
 	//go:embed resources/images/triangle_exclamation.svg
-	triangleExclamationSVG string
-	TriangleExclamationSVG = MustSVGFromContentString(triangleExclamationSVG)
+	triangleExclamationSVGContent string
+	loadTriangleExclamationSVG    = builtinSVG("triangle_exclamation.svg", triangleExclamationSVGContent)
+	TriangleExclamationSVG        = builtinSVGOrEmpty(loadTriangleExclamationSVG)
 
 	//go:embed resources/images/window_maximize.svg
-	windowMaximizeSVG string
-	WindowMaximizeSVG = MustSVGFromContentString(windowMaximizeSVG)
+	windowMaximizeSVGContent string
+	loadWindowMaximizeSVG    = builtinSVG("window_maximize.svg", windowMaximizeSVGContent)
+	WindowMaximizeSVG        = builtinSVGOrEmpty(loadWindowMaximizeSVG)
 
 	//go:embed resources/images/window_restore.svg
-	windowRestoreSVG string
-	WindowRestoreSVG = MustSVGFromContentString(windowRestoreSVG)
+	windowRestoreSVGContent string
+	loadWindowRestoreSVG    = builtinSVG("window_restore.svg", windowRestoreSVGContent)
+	WindowRestoreSVG        = builtinSVGOrEmpty(loadWindowRestoreSVG)
 )
 
+// LoadBrokenImageSVG returns the pre-defined SVG used in place of an image that could not be loaded, or the error
+// encountered while parsing it.
+func LoadBrokenImageSVG() (*SVG, error) {
+	return loadBrokenImageSVG()
+}
+
+// LoadCircledChevronRightSVG returns the pre-defined SVG of a chevron pointing right within a circle, or the error
+// encountered while parsing it.
+func LoadCircledChevronRightSVG() (*SVG, error) {
+	return loadCircledChevronRightSVG()
+}
+
+// LoadCircledExclamationSVG returns the pre-defined SVG of an exclamation point within a circle, or the error
+// encountered while parsing it.
+func LoadCircledExclamationSVG() (*SVG, error) {
+	return loadCircledExclamationSVG()
+}
+
+// LoadCircledQuestionSVG returns the pre-defined SVG of a question mark within a circle, or the error encountered while
+// parsing it.
+func LoadCircledQuestionSVG() (*SVG, error) {
+	return loadCircledQuestionSVG()
+}
+
+// LoadCheckmarkSVG returns the pre-defined SVG of a checkmark, or the error encountered while parsing it.
+func LoadCheckmarkSVG() (*SVG, error) {
+	return loadCheckmarkSVG()
+}
+
+// LoadChevronRightSVG returns the pre-defined SVG of a chevron pointing right, or the error encountered while parsing
+// it.
+func LoadChevronRightSVG() (*SVG, error) {
+	return loadChevronRightSVG()
+}
+
+// LoadCircledXSVG returns the pre-defined SVG of an X within a circle, or the error encountered while parsing it.
+func LoadCircledXSVG() (*SVG, error) {
+	return loadCircledXSVG()
+}
+
+// LoadDashSVG returns the pre-defined SVG of a dash, or the error encountered while parsing it.
+func LoadDashSVG() (*SVG, error) {
+	return loadDashSVG()
+}
+
+// LoadDocumentSVG returns the pre-defined SVG of a document, or the error encountered while parsing it.
+func LoadDocumentSVG() (*SVG, error) {
+	return loadDocumentSVG()
+}
+
+// LoadSortAscendingSVG returns the pre-defined SVG used to indicate an ascending sort, or the error encountered while
+// parsing it.
+func LoadSortAscendingSVG() (*SVG, error) {
+	return loadSortAscendingSVG()
+}
+
+// LoadSortDescendingSVG returns the pre-defined SVG used to indicate a descending sort, or the error encountered while
+// parsing it.
+func LoadSortDescendingSVG() (*SVG, error) {
+	return loadSortDescendingSVG()
+}
+
+// LoadTriangleExclamationSVG returns the pre-defined SVG of an exclamation point within a triangle, or the error
+// encountered while parsing it.
+func LoadTriangleExclamationSVG() (*SVG, error) {
+	return loadTriangleExclamationSVG()
+}
+
+// LoadWindowMaximizeSVG returns the pre-defined SVG used for maximizing a window, or the error encountered while
+// parsing it.
+func LoadWindowMaximizeSVG() (*SVG, error) {
+	return loadWindowMaximizeSVG()
+}
+
+// LoadWindowRestoreSVG returns the pre-defined SVG used for restoring a maximized window, or the error encountered
+// while parsing it.
+func LoadWindowRestoreSVG() (*SVG, error) {
+	return loadWindowRestoreSVG()
+}
+
+// builtinSVG returns a function that loads the embedded SVG content on its first call and returns the same result on
+// every call thereafter.
+func builtinSVG(name, content string) func() (*SVG, error) {
+	return sync.OnceValues(func() (*SVG, error) { return NewNamedSVGFromContentString(name, content) })
+}
+
+// builtinSVGOrEmpty returns the SVG from one of the pre-defined SVG accessors. Should the SVG fail to load, the error
+// is logged and an empty SVG is returned in its place.
+func builtinSVGOrEmpty(accessor func() (*SVG, error)) *SVG {
+	svg, err := accessor()
+	if err != nil {
+		errs.Log(err)
+		return &SVG{size: Size{Width: 1, Height: 1}, unscaledPath: NewPath(), scaledPathMap: make(map[Size]*Path)}
+	}
+	return svg
+}
+
 // DrawableSVG makes an SVG conform to the Drawable interface.
 type DrawableSVG struct {
-	SVG  *SVG
-	Size Size
+	SVG *SVG
+	// Antialias, if not nil, overrides the antialiasing setting of the paint used to draw the SVG.
+	Antialias *bool
+	Size      Size
+	// DrawMode determines whether the SVG's paths are drawn as a single combined path or individually. Drawing
+	// individually is always done when any per-element overrides have been set on the SVG.
+	DrawMode svgdrawmode.Enum
+	// PixelSnap causes the area the SVG is drawn into to be aligned to device pixel boundaries, taking the current
+	// canvas scale into account. This produces crisper results for small icons.
+	PixelSnap bool
 }
 
-// SVG holds an SVG.
+// SVG holds an SVG. An SVG may be drawn from multiple places at once, such as the pre-defined images being used in many
+// windows, as its internal caches are protected. Element overrides, however, affect every use of the SVG and should
+// only be changed from the UI thread.
 type SVG struct {
 	unscaledPath  *Path
 	scaledPathMap map[Size]*Path
+	elements      []*svgElement
+	images        []*svgImage
 	size          Size
+	lock          sync.Mutex
+}
+
+// svgImage holds a raster image element from an SVG.
+type svgImage struct {
+	img  *Image
+	rect Rect
+}
+
+// svgLoader holds the settings used while loading an SVG.
+type svgLoader struct {
+	baseDir          string
+	imageLoadTimeout time.Duration
+	externalRefs     bool
+}
+
+// DefaultSVGImageLoadTimeout is the default amount of time allowed for loading each external image referenced by an
+// SVG.
+const DefaultSVGImageLoadTimeout = 30 * time.Second
+
+// SVGOption holds an option for SVG loading.
+type SVGOption func(*svgLoader) error
+
+// ExternalReferencesSVGOption permits images referenced by the SVG to be loaded from the file system or the network.
+// Relative references are resolved against baseDir, or against the current working directory if baseDir is empty.
+// Without this option, only images embedded within the SVG as data URIs are loaded.
+func ExternalReferencesSVGOption(baseDir string) SVGOption {
+	return func(loader *svgLoader) error {
+		loader.externalRefs = true
+		loader.baseDir = baseDir
+		return nil
+	}
+}
+
+// ImageLoadTimeoutSVGOption sets the amount of time allowed for loading each external image referenced by the SVG.
+// Without this option, DefaultSVGImageLoadTimeout is used.
+func ImageLoadTimeoutSVGOption(timeout time.Duration) SVGOption {
+	return func(loader *svgLoader) error {
+		if timeout <= 0 {
+			return errs.New("image load timeout must be greater than zero")
+		}
+		loader.imageLoadTimeout = timeout
+		return nil
+	}
+}
+
+// NoExternalReferencesSVGOption prevents images referenced by the SVG from being loaded from the file system or the
+// network, overriding an earlier ExternalReferencesSVGOption, such as the one NewSVGFromFile() supplies. Image
+// elements with such references are ignored. Images embedded within the SVG as data URIs are still loaded.
+func NoExternalReferencesSVGOption() SVGOption {
+	return func(loader *svgLoader) error {
+		loader.externalRefs = false
+		return nil
+	}
+}
+
+// svgElement holds an individual path element from an SVG, along with any paint overrides applied to it.
+type svgElement struct {
+	unscaledPath  *Path
+	scaledPathMap map[float32]*Path
+	fill          *Color
+	stroke        *Color
+	id            string
+	strokeWidth   float32
 }
 
 // MustSVG creates a new SVG the given svg path string (the contents of a single "d" attribute from an SVG "path"
@@ -119,38 +319,92 @@ func NewSVG(size Size, svg string) (*SVG, error) {
 
 // MustSVGFromContentString creates a new SVG and panics if an error would be generated. The content should contain
 // valid SVG file data. Note that this only reads a very small subset of an SVG currently. Specifically, the "viewBox"
-// attribute and any "d" attributes from enclosed SVG "path" elements.
+// attribute and any "d" and "id" attributes from enclosed SVG "path" elements.
 func MustSVGFromContentString(content string) *SVG {
 	s, err := NewSVGFromContentString(content)
 	fatal.IfErr(err)
 	return s
 }
 
+// MustNamedSVGFromContentString creates a new SVG and panics if an error would be generated. The name is included in
+// the panic message to identify the SVG that failed, which is useful for embedded assets that are loaded during package
+// initialization.
+func MustNamedSVGFromContentString(name, content string) *SVG {
+	s, err := NewNamedSVGFromContentString(name, content)
+	fatal.IfErr(err)
+	return s
+}
+
+// NewNamedSVGFromContentString creates a new SVG. This is the same as NewSVGFromContentString, except that any error
+// returned will identify the SVG by the given name.
+func NewNamedSVGFromContentString(name, content string) (*SVG, error) {
+	s, err := NewSVGFromContentString(content)
+	if err != nil {
+		return nil, errs.NewWithCause("unable to load SVG: "+name, err)
+	}
+	return s, nil
+}
+
 // NewSVGFromContentString creates a new SVG. The content should contain valid SVG file data. Note that this only reads
-// a very small subset of an SVG currently. Specifically, the "viewBox" attribute and any "d" attributes from enclosed
-// SVG "path" elements.
+// a very small subset of an SVG currently. Specifically, the "viewBox" attribute and any "d" and "id" attributes from
+// enclosed SVG "path" elements.
 func NewSVGFromContentString(content string) (*SVG, error) {
 	return NewSVGFromReader(strings.NewReader(content))
 }
 
 // MustSVGFromReader creates a new SVG and panics if an error would be generated. The reader should contain valid SVG
 // file data. Note that this only reads a very small subset of an SVG currently. Specifically, the "viewBox" attribute
-// and any "d" attributes from enclosed SVG "path" elements.
+// and any "d" and "id" attributes from enclosed SVG "path" elements.
 func MustSVGFromReader(r io.Reader) *SVG {
 	s, err := NewSVGFromReader(r)
 	fatal.IfErr(err)
 	return s
 }
 
+// NewSVGFromFile creates a new SVG from the file at the given path. External images referenced by the file are loaded,
+// with relative references resolved against the directory containing the file, unless overridden by a later
+// ExternalReferencesSVGOption or NoExternalReferencesSVGOption. See NewSVGFromReader() for the subset of SVG that is
+// supported.
+func NewSVGFromFile(path string, options ...SVGOption) (*SVG, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errs.NewWithCause("unable to open SVG file", err)
+	}
+	defer xio.CloseIgnoringErrors(f)
+	options = append([]SVGOption{ExternalReferencesSVGOption(filepath.Dir(path))}, options...)
+	var svg *SVG
+	if svg, err = NewSVGFromReader(f, options...); err != nil {
+		return nil, errs.NewWithCausef(err, "unable to load SVG file: %s", path)
+	}
+	return svg, nil
+}
+
 // NewSVGFromReader creates a new SVG. The reader should contain valid SVG file data. Note that this only reads a very
-// small subset of an SVG currently. Specifically, the "viewBox" attribute and any "d" attributes from enclosed SVG
-// "path" elements.
-func NewSVGFromReader(r io.Reader) (*SVG, error) {
+// small subset of an SVG currently. Specifically, the "viewBox" attribute, any "d" and "id" attributes from enclosed
+// SVG "path" elements, and the "x", "y", "width", "height" and "href" attributes from enclosed SVG "image" elements.
+// Images may be embedded as base64 or percent-encoded data URIs and are drawn beneath the paths. Images referenced from
+// the file system or the network are ignored unless permitted by an ExternalReferencesSVGOption. Images that fail to
+// load are logged and skipped.
+func NewSVGFromReader(r io.Reader, options ...SVGOption) (*SVG, error) {
+	loader := svgLoader{imageLoadTimeout: DefaultSVGImageLoadTimeout}
+	for _, option := range options {
+		if err := option(&loader); err != nil {
+			return nil, err
+		}
+	}
 	var svgXML struct {
 		ViewBox string `xml:"viewBox,attr"`
 		Paths   []struct {
+			ID   string `xml:"id,attr"`
 			Path string `xml:"d,attr"`
 		} `xml:"path"`
+		Images []struct {
+			Href   string  `xml:"href,attr"`
+			X      float32 `xml:"x,attr"`
+			Y      float32 `xml:"y,attr"`
+			Width  float32 `xml:"width,attr"`
+			Height float32 `xml:"height,attr"`
+		} `xml:"image"`
 	}
 	if err := xml.NewDecoder(r).Decode(&svgXML); err != nil {
 		return nil, errs.NewWithCause("unable to decode SVG", err)
@@ -176,15 +430,79 @@ func NewSVGFromReader(r io.Reader) (*SVG, error) {
 		if p, err = NewPathFromSVGString(svgPath.Path); err != nil {
 			return nil, errs.NewWithCausef(err, "unable to decode SVG: path element #%d", i)
 		}
+		svg.elements = append(svg.elements, &svgElement{
+			id:            svgPath.ID,
+			unscaledPath:  p.Clone(),
+			scaledPathMap: make(map[float32]*Path),
+		})
 		if svg.unscaledPath == nil {
 			svg.unscaledPath = p
 		} else {
 			svg.unscaledPath.Path(p, false)
 		}
 	}
+	for i, svgImg := range svgXML.Images {
+		var img *Image
+		if img, err = loader.loadImage(strings.TrimSpace(svgImg.Href)); err != nil {
+			errs.Log(errs.NewWithCausef(err, "unable to load SVG image element #%d; skipping it", i))
+			continue
+		}
+		if img == nil {
+			continue
+		}
+		rect := NewRect(svgImg.X, svgImg.Y, svgImg.Width, svgImg.Height)
+		if rect.Width <= 0 || rect.Height <= 0 {
+			rect.Size = img.LogicalSize()
+		}
+		svg.images = append(svg.images, &svgImage{img: img, rect: rect})
+	}
+	if svg.unscaledPath == nil {
+		svg.unscaledPath = NewPath()
+	}
 	return svg, nil
 }
 
+// loadImage loads the image referenced by href. Returns nil without an error if the reference is to an external image
+// and external references have not been permitted.
+func (l *svgLoader) loadImage(href string) (*Image, error) {
+	if href == "" {
+		return nil, errs.New("missing href")
+	}
+	if rest, ok := strings.CutPrefix(href, "data:"); ok {
+		meta, data, found := strings.Cut(rest, ",")
+		if !found {
+			return nil, errs.New("invalid data URI")
+		}
+		var buffer []byte
+		if strings.HasSuffix(meta, ";base64") {
+			// Embedded data is frequently broken across multiple lines, so strip any whitespace before decoding
+			var err error
+			if buffer, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), "")); err != nil {
+				return nil, errs.NewWithCause("invalid base64 data", err)
+			}
+		} else {
+			unescaped, err := url.PathUnescape(data)
+			if err != nil {
+				return nil, errs.NewWithCause("invalid percent-encoded data", err)
+			}
+			buffer = []byte(unescaped)
+		}
+		return NewImageFromBytes(buffer, 1)
+	}
+	if !l.externalRefs {
+		return nil, nil
+	}
+	if !strings.Contains(href, "://") {
+		href = strings.TrimPrefix(href, "file:")
+		if !filepath.IsAbs(href) && l.baseDir != "" {
+			href = filepath.Join(l.baseDir, filepath.FromSlash(href))
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), l.imageLoadTimeout)
+	defer cancel()
+	return NewImageFromFilePathOrURLWithContext(ctx, href, 1)
+}
+
 // Size returns the original size.
 func (s *SVG) Size() Size {
 	return s.size
@@ -203,6 +521,8 @@ func (s *SVG) PathScaledTo(scale float32) *Path {
 		return s.unscaledPath
 	}
 	scaledSize := Size{Width: scale, Height: scale}
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	p, ok := s.scaledPathMap[scaledSize]
 	if !ok {
 		p = s.unscaledPath.NewScaled(scale, scale)
@@ -216,6 +536,114 @@ func (s *SVG) PathForSize(size Size) *Path {
 	return s.PathScaledTo(min(size.Width/s.size.Width, size.Height/s.size.Height))
 }
 
+// ElementIDs returns the ids of the path elements that were present in the original SVG content, in document order.
+// Path elements without an id are not included.
+func (s *SVG) ElementIDs() []string {
+	var ids []string
+	for _, elem := range s.elements {
+		if elem.id != "" {
+			ids = append(ids, elem.id)
+		}
+	}
+	return ids
+}
+
+// SetElementFill causes any path elements with the given id to be filled with the specified color on subsequent
+// draws, rather than with the paint supplied to the draw call.
+func (s *SVG) SetElementFill(id string, color Color) {
+	for _, elem := range s.elements {
+		if elem.id == id {
+			c := color
+			elem.fill = &c
+		}
+	}
+}
+
+// SetElementStroke causes any path elements with the given id to be outlined with the specified color and stroke
+// width on subsequent draws. The stroke is drawn on top of the element's fill.
+func (s *SVG) SetElementStroke(id string, color Color, width float32) {
+	for _, elem := range s.elements {
+		if elem.id == id {
+			c := color
+			elem.stroke = &c
+			elem.strokeWidth = width
+		}
+	}
+}
+
+// ClearElementOverrides removes any fill or stroke overrides previously set for path elements with the given id.
+func (s *SVG) ClearElementOverrides(id string) {
+	for _, elem := range s.elements {
+		if elem.id == id {
+			elem.fill = nil
+			elem.stroke = nil
+			elem.strokeWidth = 0
+		}
+	}
+}
+
+func (s *SVG) hasElementOverrides() bool {
+	for _, elem := range s.elements {
+		if elem.fill != nil || elem.stroke != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *svgElement) pathScaledTo(scale float32) *Path {
+	if scale == 1 {
+		return e.unscaledPath
+	}
+	p, ok := e.scaledPathMap[scale]
+	if !ok {
+		p = e.unscaledPath.NewScaled(scale, scale)
+		e.scaledPathMap[scale] = p
+	}
+	return p
+}
+
+// drawElements draws each path element individually, applying any overrides.
+func (s *SVG) drawElements(canvas *Canvas, scale float32, paint *Paint) {
+	if paint == nil {
+		paint = NewPaint()
+	}
+	for _, elem := range s.elements {
+		s.lock.Lock()
+		path := elem.pathScaledTo(scale)
+		s.lock.Unlock()
+		if elem.fill != nil {
+			fill := paint.Clone()
+			fill.SetShader(nil)
+			fill.SetStyle(paintstyle.Fill)
+			fill.SetColor(*elem.fill)
+			canvas.DrawPath(path, fill)
+		} else {
+			canvas.DrawPath(path, paint)
+		}
+		if elem.stroke != nil {
+			stroke := paint.Clone()
+			stroke.SetShader(nil)
+			stroke.SetStyle(paintstyle.Stroke)
+			stroke.SetStrokeWidth(elem.strokeWidth)
+			stroke.SetColor(*elem.stroke)
+			canvas.DrawPath(path, stroke)
+		}
+	}
+}
+
+// drawImages draws any raster image elements.
+func (s *SVG) drawImages(canvas *Canvas, scale float32) {
+	for _, one := range s.images {
+		r := one.rect
+		r.X *= scale
+		r.Y *= scale
+		r.Width *= scale
+		r.Height *= scale
+		canvas.DrawImageInRect(one.img, r, nil, nil)
+	}
+}
+
 // LogicalSize implements the Drawable interface.
 func (s *DrawableSVG) LogicalSize() Size {
 	return s.Size
@@ -226,6 +654,44 @@ func (s *DrawableSVG) DrawInRect(canvas *Canvas, rect Rect, _ *SamplingOptions,
 	canvas.Save()
 	defer canvas.Restore()
 	offset := s.SVG.OffsetToCenterWithinScaledSize(rect.Size)
-	canvas.Translate(rect.X+offset.X, rect.Y+offset.Y)
-	canvas.DrawPath(s.SVG.PathForSize(rect.Size), paint)
+	scale := min(rect.Width/s.SVG.size.Width, rect.Height/s.SVG.size.Height)
+	r := Rect{
+		Point: Point{X: rect.X + offset.X, Y: rect.Y + offset.Y},
+		Size:  Size{Width: s.SVG.size.Width * scale, Height: s.SVG.size.Height * scale},
+	}
+	if s.PixelSnap {
+		r = snapRectToPixels(canvas.Matrix(), r)
+		scale = min(r.Width/s.SVG.size.Width, r.Height/s.SVG.size.Height)
+	}
+	canvas.Translate(r.X, r.Y)
+	if s.Antialias != nil {
+		if paint == nil {
+			paint = NewPaint()
+		} else {
+			paint = paint.Clone()
+		}
+		paint.SetAntialias(*s.Antialias)
+	}
+	s.SVG.drawImages(canvas, scale)
+	if s.DrawMode == svgdrawmode.PerPath || s.SVG.hasElementOverrides() {
+		s.SVG.drawElements(canvas, scale, paint)
+		return
+	}
+	canvas.DrawPath(s.SVG.PathScaledTo(scale), paint)
+}
+
+// snapRectToPixels returns the rect adjusted such that its edges fall on device pixel boundaries when transformed by
+// the matrix. Matrices with skew or non-positive scaling are not supported and result in the rect being returned as-is.
+func snapRectToPixels(m Matrix, rect Rect) Rect {
+	if m.SkewX != 0 || m.SkewY != 0 || m.ScaleX <= 0 || m.ScaleY <= 0 {
+		return rect
+	}
+	left := xmath.Round(rect.X*m.ScaleX + m.TransX)
+	top := xmath.Round(rect.Y*m.ScaleY + m.TransY)
+	right := max(xmath.Round(rect.Right()*m.ScaleX+m.TransX), left+1)
+	bottom := max(xmath.Round(rect.Bottom()*m.ScaleY+m.TransY), top+1)
+	return Rect{
+		Point: Point{X: (left - m.TransX) / m.ScaleX, Y: (top - m.TransY) / m.ScaleY},
+		Size:  Size{Width: (right - left) / m.ScaleX, Height: (bottom - top) / m.ScaleY},
+	}
 }
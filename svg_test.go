@@ -0,0 +1,136 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func redPNG(t *testing.T) []byte {
+	t.Helper()
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	check.NoError(t, png.Encode(&buffer, src))
+	return buffer.Bytes()
+}
+
+func drawSVG(t *testing.T, svg *unison.SVG) *image.NRGBA {
+	t.Helper()
+	surface, err := unison.NewSoftwareSurface(unison.NewSize(8, 8), 1)
+	check.NoError(t, err)
+	defer surface.Dispose()
+	surface.Canvas().Clear(unison.Transparent)
+	drawable := &unison.DrawableSVG{SVG: svg, Size: svg.Size()}
+	drawable.DrawInRect(surface.Canvas(), unison.NewRect(0, 0, 8, 8), nil, unison.NewPaint())
+	img, err := surface.SnapshotImage()
+	check.NoError(t, err)
+	nrgba, err := img.ToNRGBA()
+	check.NoError(t, err)
+	return nrgba
+}
+
+func TestSVGEmbeddedImage(t *testing.T) {
+	// Break the base64 data across lines, as is common in files produced by editors
+	var data strings.Builder
+	for encoded := base64.StdEncoding.EncodeToString(redPNG(t)); encoded != ""; {
+		n := min(len(encoded), 32)
+		data.WriteString(encoded[:n])
+		data.WriteString("\n    ")
+		encoded = encoded[n:]
+	}
+	svg, err := unison.NewSVGFromContentString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg"
+xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 8 8">
+<image x="4" y="0" width="4" height="8" xlink:href="data:image/png;base64,%s"/>
+</svg>`, data.String()))
+	check.NoError(t, err)
+	nrgba := drawSVG(t, svg)
+	inside := nrgba.NRGBAAt(6, 4)
+	check.True(t, inside.R > 200 && inside.A > 200)
+	check.Equal(t, uint8(0), nrgba.NRGBAAt(1, 4).A)
+}
+
+func TestSVGExternalImagesAreOptIn(t *testing.T) {
+	dir := t.TempDir()
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "red.png"), redPNG(t), 0644))
+	const content = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 8 8">
+<image x="0" y="0" width="8" height="8" href="red.png"/>
+</svg>`
+	svgPath := filepath.Join(dir, "image.svg")
+	check.NoError(t, os.WriteFile(svgPath, []byte(content), 0644))
+
+	svg, err := unison.NewSVGFromContentString(content)
+	check.NoError(t, err)
+	check.Equal(t, uint8(0), drawSVG(t, svg).NRGBAAt(4, 4).A)
+
+	svg, err = unison.NewSVGFromReader(strings.NewReader(content), unison.ExternalReferencesSVGOption(dir))
+	check.NoError(t, err)
+	check.True(t, drawSVG(t, svg).NRGBAAt(4, 4).R > 200)
+
+	svg, err = unison.NewSVGFromFile(svgPath)
+	check.NoError(t, err)
+	check.True(t, drawSVG(t, svg).NRGBAAt(4, 4).R > 200)
+
+	svg, err = unison.NewSVGFromFile(svgPath, unison.NoExternalReferencesSVGOption())
+	check.NoError(t, err)
+	check.Equal(t, uint8(0), drawSVG(t, svg).NRGBAAt(4, 4).A)
+}
+
+func TestBuiltinSVGs(t *testing.T) {
+	for _, one := range []struct {
+		svg  *unison.SVG
+		load func() (*unison.SVG, error)
+	}{
+		{svg: unison.BrokenImageSVG, load: unison.LoadBrokenImageSVG},
+		{svg: unison.CircledChevronRightSVG, load: unison.LoadCircledChevronRightSVG},
+		{svg: unison.CircledExclamationSVG, load: unison.LoadCircledExclamationSVG},
+		{svg: unison.CircledQuestionSVG, load: unison.LoadCircledQuestionSVG},
+		{svg: unison.CheckmarkSVG, load: unison.LoadCheckmarkSVG},
+		{svg: unison.ChevronRightSVG, load: unison.LoadChevronRightSVG},
+		{svg: unison.CircledXSVG, load: unison.LoadCircledXSVG},
+		{svg: unison.DashSVG, load: unison.LoadDashSVG},
+		{svg: unison.DocumentSVG, load: unison.LoadDocumentSVG},
+		{svg: unison.SortAscendingSVG, load: unison.LoadSortAscendingSVG},
+		{svg: unison.SortDescendingSVG, load: unison.LoadSortDescendingSVG},
+		{svg: unison.TriangleExclamationSVG, load: unison.LoadTriangleExclamationSVG},
+		{svg: unison.WindowMaximizeSVG, load: unison.LoadWindowMaximizeSVG},
+		{svg: unison.WindowRestoreSVG, load: unison.LoadWindowRestoreSVG},
+	} {
+		svg, err := one.load()
+		check.NoError(t, err)
+		check.NotNil(t, svg)
+		check.True(t, svg == one.svg)
+	}
+}
+
+func TestSVGSkipsBrokenImage(t *testing.T) {
+	svg, err := unison.NewSVGFromContentString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 8 8">
+<image x="0" y="0" width="4" height="4" href="data:image/png;base64,!!!"/>
+<path d="M0 0L8 8"/>
+</svg>`)
+	check.NoError(t, err)
+	check.Equal(t, unison.NewSize(8, 8), svg.Size())
+}
@@ -194,7 +194,7 @@ func (t *Table[T]) DefaultDraw(canvas *Canvas, dirty Rect) {
 		selectionInk = t.InactiveSelectionInk
 	}
 
-	canvas.DrawRect(dirty, t.BackgroundInk.Paint(canvas, dirty, paintstyle.Fill))
+	canvas.FillRectWithInk(dirty, t.BackgroundInk)
 
 	var insets Insets
 	if border := t.Border(); border != nil {
@@ -236,17 +236,17 @@ func (t *Table[T]) DefaultDraw(canvas *Canvas, dirty Rect) {
 		rect.Height = t.rowCache[r].height
 		if t.IsRowOrAnyParentSelected(r) {
 			if t.IsRowSelected(r) {
-				canvas.DrawRect(rect, selectionInk.Paint(canvas, rect, paintstyle.Fill))
+				canvas.FillRectWithInk(rect, selectionInk)
 			} else {
-				canvas.DrawRect(rect, t.IndirectSelectionInk.Paint(canvas, rect, paintstyle.Fill))
+				canvas.FillRectWithInk(rect, t.IndirectSelectionInk)
 			}
 		} else if r%2 == 1 {
-			canvas.DrawRect(rect, t.BandingInk.Paint(canvas, rect, paintstyle.Fill))
+			canvas.FillRectWithInk(rect, t.BandingInk)
 		}
 		rect.Y += t.rowCache[r].height
 		if t.ShowRowDivider && r != endBeforeRow-1 {
 			rect.Height = 1
-			canvas.DrawRect(rect, t.InteriorDividerInk.Paint(canvas, rect, paintstyle.Fill))
+			canvas.FillRectWithInk(rect, t.InteriorDividerInk)
 			rect.Y++
 		}
 	}
@@ -257,7 +257,7 @@ func (t *Table[T]) DefaultDraw(canvas *Canvas, dirty Rect) {
 		rect.Width = 1
 		for c := firstCol; c < len(t.Columns)-1; c++ {
 			rect.X += t.Columns[c].Current
-			canvas.DrawRect(rect, t.InteriorDividerInk.Paint(canvas, rect, paintstyle.Fill))
+			canvas.FillRectWithInk(rect, t.InteriorDividerInk)
 			rect.X++
 		}
 	}
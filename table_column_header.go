@@ -29,10 +29,12 @@ var DefaultTableColumnHeaderTheme = LabelTheme{
 		Font:            LabelFont,
 		OnBackgroundInk: ThemeOnSurface,
 	},
-	Gap:    3,
-	HAlign: align.Middle,
-	VAlign: align.Middle,
-	Side:   side.Left,
+	SelectionInk:   ThemeFocus,
+	OnSelectionInk: ThemeOnFocus,
+	Gap:            3,
+	HAlign:         align.Middle,
+	VAlign:         align.Middle,
+	Side:           side.Left,
 }
 
 // DefaultTableColumnHeader provides a default table column header panel.
@@ -14,6 +14,7 @@ import (
 	"unicode"
 
 	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison/enums/rounding"
 )
 
 // Text holds data necessary to draw a string using font fallbacks where necessary.
@@ -22,8 +23,10 @@ type Text struct {
 	runes       []rune
 	decorations []*TextDecoration
 	widths      []float32
+	positions   []float32
 	extents     Size
 	baseline    float32
+	ascent      float32
 	emptyHeight float32
 }
 
@@ -47,6 +50,38 @@ func NewTextFromRunes(runes []rune, decoration *TextDecoration) *Text {
 	return t
 }
 
+// NewMonospaceText creates a new Text, assuming every rune has the same advance as the font's 'M' glyph. This skips
+// the per-rune glyph measurement and font fallback done by NewText(), which makes it much faster for large volumes of
+// text, such as log output. Only use this with truly monospaced fonts that contain every rune being displayed, as the
+// positions will otherwise be wrong. Note that tabs and line endings are not considered.
+func NewMonospaceText(str string, decoration *TextDecoration) *Text {
+	runes := []rune(str)
+	decoration = decoration.Clone()
+	advance := decoration.Font.SimpleWidth("M")
+	baseline := decoration.Font.Baseline()
+	height := decoration.Font.LineHeight() + xmath.Abs(decoration.BaselineOffset)
+	t := &Text{
+		runes:       runes,
+		decorations: make([]*TextDecoration, len(runes)),
+		widths:      make([]float32, len(runes)),
+		positions:   make([]float32, len(runes)+1),
+		extents:     Size{Width: advance * float32(len(runes)), Height: height},
+		baseline:    baseline,
+		ascent:      baseline,
+		emptyHeight: height,
+	}
+	if len(runes) != 0 {
+		t.ascent = max(baseline, baseline-decoration.BaselineOffset)
+	}
+	for i := range runes {
+		t.decorations[i] = decoration
+		t.widths[i] = advance
+		t.positions[i] = advance * float32(i)
+	}
+	t.positions[len(runes)] = t.extents.Width
+	return t
+}
+
 // NewTextLines creates a new list of Text, one for each logical line. Tabs are not considered, but the text is split on
 // any line feeds found.
 func NewTextLines(text string, decoration *TextDecoration) []*Text {
@@ -113,6 +148,13 @@ func NewSmallCapsText(text string, decoration *TextDecoration) *Text {
 	return t
 }
 
+// TextFits returns true if the string, drawn with the font, fits within the width without being clipped or wrapped.
+// The string is measured as a whole, without creating a Text, so font fallback is not done. Note that tabs and line
+// endings are not considered.
+func TextFits(text string, font Font, width float32) bool {
+	return font.SimpleWidth(text) <= width
+}
+
 // Empty returns true if this doesn't hold any characters. May be called on a nil *Text.
 func (t *Text) Empty() bool {
 	return t == nil || len(t.runes) == 0
@@ -129,6 +171,7 @@ func (t *Text) Slice(i, j int) *Text {
 	if i >= j {
 		return &Text{
 			baseline:    t.baseline,
+			ascent:      t.baseline,
 			emptyHeight: t.emptyHeight,
 		}
 	}
@@ -170,29 +213,77 @@ func (t *Text) Width() float32 {
 	return t.extents.Width
 }
 
+// FitsWidth returns true if the Text can be drawn within the width without being clipped or wrapped.
+func (t *Text) FitsWidth(width float32) bool {
+	return t.Width() <= width
+}
+
 // Height returns the height.
 func (t *Text) Height() float32 {
 	t.cache()
 	return t.extents.Height
 }
 
-// Baseline returns the baseline that will be used, which is based on the original font passed in at creation time.
+// Baseline returns the common baseline shared by all runs of the text, as measured from the top. When the text contains
+// runs with differing fonts or baseline offsets, this is the largest ascent of them, so that all runs align on the
+// same baseline without any of them extending above the top. For empty text, the baseline of the original font passed
+// in at creation time is used.
 func (t *Text) Baseline() float32 {
-	return t.baseline
+	t.cache()
+	return t.ascent
 }
 
 func (t *Text) cache() {
 	if t.extents.Width < 0 {
 		t.extents.Width = 0
 		t.extents.Height = t.emptyHeight
+		t.ascent = t.baseline
+		if len(t.decorations) == 0 {
+			return
+		}
+		var descent float32
+		var last *TextDecoration
 		for i, d := range t.decorations {
-			h := d.Font.LineHeight() + xmath.Abs(d.BaselineOffset)
 			t.extents.Width += t.widths[i]
-			if t.extents.Height < h {
-				t.extents.Height = h
+			if d != last {
+				last = d
+				fontBaseline := d.Font.Baseline()
+				t.ascent = max(t.ascent, fontBaseline-d.BaselineOffset)
+				descent = max(descent, d.Font.LineHeight()-fontBaseline+d.BaselineOffset)
 			}
 		}
+		t.extents.Height = t.ascent + descent
+	}
+}
+
+// cachePositions computes the starting x-coordinate of each rune, plus the trailing edge of the last one, if not
+// already done.
+func (t *Text) cachePositions() {
+	if t.positions == nil {
+		t.positions = make([]float32, len(t.widths)+1)
+		var x float32
+		for i, w := range t.widths {
+			t.positions[i] = x
+			x += w
+		}
+		t.positions[len(t.widths)] = x
+	}
+}
+
+// GlyphAdvances returns the horizontal advance of each rune, in rune order. Do not modify this slice.
+func (t *Text) GlyphAdvances() []float32 {
+	return t.widths
+}
+
+// GlyphPositions returns the position of each rune relative to the start of the text, in rune order. The x-coordinate
+// is where the rune starts and the y-coordinate is the baseline offset from the rune's decoration.
+func (t *Text) GlyphPositions() []Point {
+	t.cachePositions()
+	pts := make([]Point, len(t.decorations))
+	for i, d := range t.decorations {
+		pts[i] = Point{X: t.positions[i], Y: d.BaselineOffset}
 	}
+	return pts
 }
 
 // AddString adds a string with the given decoration to this Text.
@@ -208,6 +299,7 @@ func (t *Text) AddRunes(runes []rune, decoration *TextDecoration) {
 	}
 	t.text = ""
 	t.extents.Width = -1
+	t.positions = nil
 	start := len(t.decorations)
 	if start != 0 && decoration.Equivalent(t.decorations[start-1]) {
 		decoration = t.decorations[start-1]
@@ -283,8 +375,14 @@ func (t *Text) Draw(canvas *Canvas, x, y float32) {
 }
 
 // RuneIndexForPosition returns the rune index within the string for the specified x-coordinate, where 0 is the start of
-// the string.
+// the string. A position that falls within a rune is resolved to the nearest rune boundary.
 func (t *Text) RuneIndexForPosition(x float32) int {
+	return t.RuneIndexForPositionWithRounding(x, rounding.Nearest)
+}
+
+// RuneIndexForPositionWithRounding returns the rune index within the string for the specified x-coordinate, where 0 is
+// the start of the string. The mode determines how a position that falls within a rune is resolved.
+func (t *Text) RuneIndexForPositionWithRounding(x float32, mode rounding.Enum) int {
 	if x <= 0 || len(t.widths) == 0 {
 		return 0
 	}
@@ -292,10 +390,17 @@ func (t *Text) RuneIndexForPosition(x float32) int {
 	for i, w := range t.widths {
 		nx += w
 		if x < nx {
-			if x > nx-w/2 {
+			switch mode {
+			case rounding.Floor:
+				return i
+			case rounding.Ceil:
 				return i + 1
+			default:
+				if x > nx-w/2 {
+					return i + 1
+				}
+				return i
 			}
-			return i
 		}
 	}
 	return len(t.widths)
@@ -307,24 +412,48 @@ func (t *Text) PositionForRuneIndex(index int) float32 {
 	if index <= 0 || len(t.widths) == 0 {
 		return 0
 	}
-	var x float32
-	for i, w := range t.widths {
-		if i >= index {
-			break
-		}
-		x += w
-	}
-	return x
+	t.cachePositions()
+	return t.positions[min(index, len(t.widths))]
+}
+
+// LineBreak holds one line produced by BreakToWidthDetailed.
+type LineBreak struct {
+	Text *Text
+	// HardBreak is true if the line was broken in the middle of a word because the word was too wide to fit on a line
+	// by itself, rather than at a natural break point.
+	HardBreak bool
 }
 
-// BreakToWidth breaks the given text into multiple lines that are <= width. Trailing whitespace is not considered for
-// purposes of fitting within the given width. A minimum of one word will be placed on a line, even if that word is
-// wider than the given width.
+// BreakToWidth breaks the given text into multiple lines that are <= width, using DefaultWordBreaker to determine where
+// lines may be broken. Trailing whitespace is not considered for purposes of fitting within the given width. A minimum
+// of one word will be placed on a line, even if that word is wider than the given width.
 func (t *Text) BreakToWidth(width float32) []*Text {
+	return t.BreakToWidthUsing(width, DefaultWordBreaker)
+}
+
+// BreakToWidthUsing breaks the given text into multiple lines in the same manner as BreakToWidth, but uses the
+// provided WordBreaker to determine where lines may be broken.
+func (t *Text) BreakToWidthUsing(width float32, breaker WordBreaker) []*Text {
+	breaks := t.breakToWidth(width, false, breaker)
+	lines := make([]*Text, len(breaks))
+	for i, one := range breaks {
+		lines[i] = one.Text
+	}
+	return lines
+}
+
+// BreakToWidthDetailed breaks the given text into multiple lines that are <= width, in the same manner as
+// BreakToWidth, except that words wider than the given width are broken mid-word, with the affected lines marked as
+// hard breaks. A minimum of one rune will be placed on a line.
+func (t *Text) BreakToWidthDetailed(width float32) []LineBreak {
+	return t.breakToWidth(width, true, DefaultWordBreaker)
+}
+
+func (t *Text) breakToWidth(width float32, splitWords bool, breaker WordBreaker) []LineBreak {
 	if t.Width() <= width {
-		return []*Text{t}
+		return []LineBreak{{Text: t}}
 	}
-	var lines []*Text
+	var lines []LineBreak
 	start := 0
 	for start < len(t.runes) {
 		i := start
@@ -334,30 +463,38 @@ func (t *Text) BreakToWidth(width float32) []*Text {
 			i++
 		}
 		if i == len(t.runes) {
-			lines = append(lines, t.Slice(start, len(t.runes)))
+			lines = append(lines, LineBreak{Text: t.Slice(start, len(t.runes))})
 			break
 		}
+		fit := i
 		// Forward past any additional whitespace
 		for i < len(t.runes) && unicode.IsSpace(t.runes[i]) {
 			i++
 		}
 		// Backup to first break
-		for i > start && !isWordBreak(t.runes[i-1]) {
+		for i > start && !breaker.CanBreakAfter(t.runes, i-1) {
 			i--
 		}
+		hard := false
 		if i == start {
-			// Nothing found that fits, so take the first word and any trailing whitespace after it
-			for i < len(t.runes) && !isWordBreak(t.runes[i]) {
-				i++
-			}
-			if i < len(t.runes) && isWordBreak(t.runes[i]) {
-				i++
-			}
-			for i < len(t.runes) && unicode.IsSpace(t.runes[i]) {
-				i++
+			if splitWords {
+				// Nothing found that fits, so take as much of the word as fits, but at least one rune
+				i = max(fit, start+1)
+				hard = true
+			} else {
+				// Nothing found that fits, so take the first word and any trailing whitespace after it
+				for i < len(t.runes) && !breaker.CanBreakAfter(t.runes, i) {
+					i++
+				}
+				if i < len(t.runes) && breaker.CanBreakAfter(t.runes, i) {
+					i++
+				}
+				for i < len(t.runes) && unicode.IsSpace(t.runes[i]) {
+					i++
+				}
 			}
 		}
-		lines = append(lines, t.Slice(start, i))
+		lines = append(lines, LineBreak{Text: t.Slice(start, i), HardBreak: hard})
 		start = i
 	}
 	return lines
@@ -32,3 +32,13 @@ func newTextBlob(textBlob skia.TextBlob) *TextBlob {
 	})
 	return tb
 }
+
+// Dispose releases the underlying resources immediately, rather than waiting for the garbage collector to do so. The
+// text blob must not be used after this call. Must be called on the UI thread.
+func (tb *TextBlob) Dispose() {
+	if tb.blob != nil {
+		runtime.SetFinalizer(tb, nil)
+		skia.TextBlobUnref(tb.blob)
+		tb.blob = nil
+	}
+}
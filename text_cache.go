@@ -0,0 +1,126 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import "container/list"
+
+// DefaultTextCacheCapacity is the capacity of the TextCache used by Canvas.DrawCachedText().
+const DefaultTextCacheCapacity = 1024
+
+var defaultTextCache = NewTextCache(DefaultTextCacheCapacity)
+
+type textCacheKey struct {
+	face *FontFace
+	key  string
+	text string
+	size float32
+}
+
+type textCacheEntry struct {
+	key   textCacheKey
+	text  *Text
+	blobs []*TextBlob
+}
+
+// TextCache holds shaped text, keyed by a caller-supplied key, the string, and the font, so that repeated draws of the
+// same string can skip shaping. Once the cache reaches its capacity, the least recently used entries are discarded. A
+// TextCache is not safe for concurrent use and should only be used on the UI thread.
+type TextCache struct {
+	entries  map[textCacheKey]*list.Element
+	lru      list.List
+	capacity int
+}
+
+// NewTextCache creates a new TextCache that holds at most capacity entries. A capacity less than 1 is treated as 1.
+func NewTextCache(capacity int) *TextCache {
+	return &TextCache{
+		entries:  make(map[textCacheKey]*list.Element),
+		capacity: max(capacity, 1),
+	}
+}
+
+// Len returns the number of entries currently in the cache.
+func (c *TextCache) Len() int {
+	return c.lru.Len()
+}
+
+// Clear removes all entries from the cache.
+func (c *TextCache) Clear() {
+	clear(c.entries)
+	c.lru.Init()
+}
+
+// Text returns the shaped Text for the string and font, creating and caching it if necessary. The returned Text is
+// shared and must not be modified.
+func (c *TextCache) Text(key, text string, font Font) *Text {
+	return c.entry(key, text, font).text
+}
+
+// Draw the string using the cached shaping data. y is where the baseline of the text will be placed.
+func (c *TextCache) Draw(canvas *Canvas, key, text string, font Font, x, y float32, paint *Paint) {
+	if text == "" {
+		return
+	}
+	for _, blob := range c.entry(key, text, font).blobs {
+		canvas.DrawTextBlob(blob, x, y, paint)
+	}
+}
+
+func (c *TextCache) entry(key, text string, font Font) *textCacheEntry {
+	k := textCacheKey{
+		face: font.Face(),
+		key:  key,
+		text: text,
+		size: font.Size(),
+	}
+	if elem, ok := c.entries[k]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*textCacheEntry)
+	}
+	e := &textCacheEntry{
+		key:  k,
+		text: NewText(text, &TextDecoration{Font: font}),
+	}
+	e.blobs = e.text.textBlobs()
+	c.entries[k] = c.lru.PushFront(e)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		delete(c.entries, oldest.Value.(*textCacheEntry).key)
+		c.lru.Remove(oldest)
+	}
+	return e
+}
+
+// textBlobs returns a TextBlob for each run of runes sharing the same font, positioned relative to the start of the
+// text and its baseline.
+func (t *Text) textBlobs() []*TextBlob {
+	t.cachePositions()
+	var blobs []*TextBlob
+	start := 0
+	for i := 1; i <= len(t.decorations); i++ {
+		if i < len(t.decorations) && t.decorations[i].Font == t.decorations[start].Font {
+			continue
+		}
+		d := t.decorations[start]
+		if blob := d.Font.TextBlobPosH(d.Font.RunesToGlyphs(t.runes[start:i]), t.positions[start:i],
+			d.BaselineOffset); blob != nil {
+			blobs = append(blobs, blob)
+		}
+		start = i
+	}
+	return blobs
+}
+
+// DrawCachedText draws the string using shaping data memoized in a shared, size-limited TextCache, keyed by the key,
+// the string, and the font. Use this for strings that are drawn repeatedly, such as axis labels. No font fallback
+// beyond that done by NewText() is performed, nor are tabs or line endings considered. y is the baseline for the text.
+func (c *Canvas) DrawCachedText(key, text string, font Font, x, y float32, paint *Paint) {
+	defaultTextCache.Draw(c, key, text, font, x, y, paint)
+}
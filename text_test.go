@@ -0,0 +1,67 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/unison"
+)
+
+func TestTextMixedSizeBaseline(t *testing.T) {
+	fd := unison.LabelFont.Descriptor()
+	fd.Size = 12
+	small := fd.Font()
+	fd.Size = 24
+	large := fd.Font()
+	check.True(t, large.Baseline() > small.Baseline())
+
+	// The common baseline must be that of the larger font, regardless of which run comes first
+	text := unison.NewText("small ", &unison.TextDecoration{Font: small})
+	text.AddString("LARGE", &unison.TextDecoration{Font: large})
+	check.Equal(t, large.Baseline(), text.Baseline())
+	check.True(t, xmath.Abs(text.Height()-large.LineHeight()) < 0.001)
+
+	text = unison.NewText("LARGE", &unison.TextDecoration{Font: large})
+	text.AddString(" small", &unison.TextDecoration{Font: small})
+	check.Equal(t, large.Baseline(), text.Baseline())
+	check.True(t, xmath.Abs(text.Height()-large.LineHeight()) < 0.001)
+
+	// A single font's baseline is unchanged
+	text = unison.NewText("small", &unison.TextDecoration{Font: small})
+	check.Equal(t, small.Baseline(), text.Baseline())
+}
+
+func TestTextCacheEviction(t *testing.T) {
+	cache := unison.NewTextCache(2)
+	first := cache.Text("", "one", unison.LabelFont)
+	check.Equal(t, "one", first.String())
+	check.True(t, first == cache.Text("", "one", unison.LabelFont))
+	check.True(t, first != cache.Text("other", "one", unison.LabelFont))
+	check.Equal(t, 2, cache.Len())
+
+	// "one" under the empty key was used least recently, so it is the entry discarded here
+	cache.Text("", "two", unison.LabelFont)
+	check.Equal(t, 2, cache.Len())
+	check.True(t, first != cache.Text("", "one", unison.LabelFont))
+
+	cache.Clear()
+	check.Equal(t, 0, cache.Len())
+}
+
+func TestTextFits(t *testing.T) {
+	width := unison.LabelFont.SimpleWidth("Some text")
+	check.True(t, unison.TextFits("Some text", unison.LabelFont, width))
+	check.True(t, unison.TextFits("Some text", unison.LabelFont, width+1))
+	check.False(t, unison.TextFits("Some text", unison.LabelFont, width-1))
+	check.True(t, unison.TextFits("", unison.LabelFont, 0))
+}
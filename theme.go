@@ -102,6 +102,7 @@ func (t *ThemeColor) Derive(deriver func(ThemeColor) ThemeColor) *DerivedThemeCo
 		deriveFunc:   deriver,
 		lastSeen:     *t,
 		lastSeenFunc: func() ThemeColor { return *t },
+		highContrast: HighContrast,
 	}
 }
 
@@ -123,9 +124,16 @@ func (t *ThemeColor) DeriveLightness(light, dark float32) *DerivedThemeColor {
 	return t.Derive(CreateDeriveLightnessFunc(light, dark))
 }
 
-// CreateDeriveLightnessFunc returns a function that will adjust the lightness of a ThemeColor by the given amount.
+// CreateDeriveLightnessFunc returns a function that will adjust the lightness of a ThemeColor by the given amount. When
+// HighContrast is enabled, the adjustment is amplified.
 func CreateDeriveLightnessFunc(light, dark float32) func(ThemeColor) ThemeColor {
 	return func(basedOn ThemeColor) ThemeColor {
+		if HighContrast {
+			return ThemeColor{
+				Light: basedOn.Light.AdjustPerceivedLightness(light * highContrastLightnessFactor),
+				Dark:  basedOn.Dark.AdjustPerceivedLightness(dark * highContrastLightnessFactor),
+			}
+		}
 		return ThemeColor{
 			Light: basedOn.Light.AdjustPerceivedLightness(light),
 			Dark:  basedOn.Dark.AdjustPerceivedLightness(dark),
@@ -139,13 +147,15 @@ type DerivedThemeColor struct {
 	lastSeenFunc func() ThemeColor
 	derived      ThemeColor
 	lastSeen     ThemeColor
+	highContrast bool
 }
 
 // GetColor returns the current color. Here to satisfy the ColorProvider interface.
 func (t *DerivedThemeColor) GetColor() Color {
 	lastSeen := t.lastSeenFunc()
-	if t.lastSeen != lastSeen {
+	if t.lastSeen != lastSeen || t.highContrast != HighContrast {
 		t.lastSeen = lastSeen
+		t.highContrast = HighContrast
 		t.derived = t.deriveFunc(lastSeen)
 	}
 	return t.derived.GetColor()
@@ -27,6 +27,7 @@ var DefaultTooltipTheme = TooltipTheme{
 	Label:     defaultToolTipLabelTheme(),
 	Delay:     1500 * time.Millisecond,
 	Dismissal: 5 * time.Second,
+	MaxWidth:  400,
 }
 
 func defaultToolTipLabelTheme() LabelTheme {
@@ -44,6 +45,9 @@ type TooltipTheme struct {
 	Label             LabelTheme
 	Delay             time.Duration
 	Dismissal         time.Duration
+	// MaxWidth is the maximum width a tooltip will be given before its content is asked to wrap. Values <= 0 mean no
+	// limit other than the width of the window.
+	MaxWidth float32
 }
 
 type tooltipSequencer struct {
@@ -63,20 +67,22 @@ func NewTooltipBase() *Panel {
 	return tip
 }
 
-// NewTooltipWithText creates a standard text tooltip panel.
-func NewTooltipWithText(text string) *Panel {
+// NewTooltip returns a tooltip panel with a single column layout, ready to be populated with arbitrary content, such as
+// multiple lines of text, icons or keyboard shortcut hints.
+func NewTooltip() *Panel {
 	tip := NewTooltipBase()
 	tip.SetLayout(&FlexLayout{
 		Columns:  1,
 		HSpacing: StdHSpacing,
 		VSpacing: StdVSpacing,
 	})
-	for _, str := range strings.Split(text, "\n") {
-		l := NewLabel()
-		l.LabelTheme = DefaultTooltipTheme.Label
-		l.SetTitle(str)
-		tip.AddChild(l)
-	}
+	return tip
+}
+
+// NewTooltipWithText creates a standard text tooltip panel.
+func NewTooltipWithText(text string) *Panel {
+	tip := NewTooltip()
+	addTooltipText(tip, text, DefaultTooltipTheme.Label.Font)
 	return tip
 }
 
@@ -85,23 +91,47 @@ func NewTooltipWithText(text string) *Panel {
 func NewTooltipWithSecondaryText(primary, secondary string) *Panel {
 	tip := NewTooltipWithText(primary)
 	if secondary != "" {
-		for _, str := range strings.Split(secondary, "\n") {
+		desc := DefaultTooltipTheme.Label.Font.Descriptor()
+		desc.Size--
+		addTooltipText(tip, secondary, desc.Font())
+	}
+	return tip
+}
+
+// addTooltipText adds a label for each line of text to the tooltip, wrapping any lines that are wider than the
+// tooltip's maximum width allows.
+func addTooltipText(tip *Panel, text string, font Font) {
+	theme := DefaultTooltipTheme.Label
+	theme.Font = font
+	width := DefaultTooltipTheme.MaxWidth
+	if b := tip.Border(); b != nil && width > 0 {
+		width -= b.Insets().Size().Width
+	}
+	for _, str := range strings.Split(text, "\n") {
+		var lines []*Text
+		if width > 0 {
+			lines = NewTextWrappedLines(str, &theme.TextDecoration, width)
+		}
+		if len(lines) == 0 {
+			lines = []*Text{NewText(str, &theme.TextDecoration)}
+		}
+		for _, line := range lines {
 			l := NewLabel()
-			l.LabelTheme = DefaultTooltipTheme.Label
-			desc := DefaultTooltipTheme.Label.Font.Descriptor()
-			desc.Size--
-			l.LabelTheme.Font = desc.Font()
-			l.SetTitle(str)
+			l.LabelTheme = theme
+			l.Text = line
 			tip.AddChild(l)
 		}
 	}
-	return tip
 }
 
 func (ts *tooltipSequencer) show() {
 	if ts.window.tooltipSequence == ts.sequence && ts.window.Focused() {
 		tip := ts.window.lastTooltip
 		_, pref, _ := tip.Sizes(Size{})
+		if maxWidth := DefaultTooltipTheme.MaxWidth; maxWidth > 0 && pref.Width > maxWidth {
+			_, pref, _ = tip.Sizes(Size{Width: maxWidth})
+			pref.Width = min(pref.Width, maxWidth)
+		}
 		rect := Rect{Point: Point{X: ts.avoid.X, Y: ts.avoid.Bottom() + 1}, Size: pref}
 		if rect.X < 0 {
 			rect.X = 0
@@ -143,3 +173,14 @@ func (ts *tooltipSequencer) close() {
 		ts.window.root.setTooltip(nil)
 	}
 }
+
+// tooltipText returns the text of the labels within a tooltip panel, joined by spaces.
+func tooltipText(tip *Panel) string {
+	var parts []string
+	for _, child := range tip.Children() {
+		if l, ok := child.Self.(*Label); ok && l.Text != nil {
+			parts = append(parts, l.Text.String())
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -164,16 +164,17 @@ func (w *Well) DefaultDraw(canvas *Canvas, _ Rect) {
 	DrawRoundedRectBase(canvas, r, w.CornerRadius, thickness, bg, edge)
 	r = r.Inset(NewUniformInsets(wellInset))
 	radius := w.CornerRadius - (wellInset - 2)
+	canvas.Save()
+	path := NewPath()
+	path.RoundedRect(r, radius, radius)
+	canvas.ClipPath(path, pathop.Intersect, true)
+	DrawCheckerboard(canvas, r, 4, White, LightGray)
 	if pattern, ok := w.ink.(*Pattern); ok {
-		canvas.Save()
-		path := NewPath()
-		path.RoundedRect(r, radius, radius)
-		canvas.ClipPath(path, pathop.Intersect, true)
 		canvas.DrawImageInRect(pattern.Image, r, nil, nil)
-		canvas.Restore()
 	} else {
-		canvas.DrawRoundedRect(r, radius, radius, w.ink.Paint(canvas, r, paintstyle.Fill))
+		canvas.DrawRect(r, w.ink.Paint(canvas, r, paintstyle.Fill))
 	}
+	canvas.Restore()
 	if !w.Enabled() {
 		p := Black.Paint(canvas, r, paintstyle.Stroke)
 		p.SetBlendMode(blendmode.Xor)
@@ -15,6 +15,7 @@ import (
 
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/imgfmt"
 	"github.com/richardwilkes/unison/enums/paintstyle"
@@ -34,11 +35,12 @@ type wellDialog struct {
 	saturationField *Field
 	brightnessField *Field
 	cssField        *Field
+	hue             float32
+	saturation      float32
+	brightness      float32
 	syncing         bool
 }
 
-// TODO: Implement gradient selection
-
 func showWellDialog(w *Well) {
 	d := &wellDialog{
 		well:        w,
@@ -83,6 +85,9 @@ func showWellDialog(w *Well) {
 	if w.Mask&ColorWellMask != 0 {
 		d.addColorSelector(right)
 	}
+	if w.Mask&GradientWellMask != 0 {
+		d.addGradientSelector(right)
+	}
 
 	var err error
 	d.dialog, err = NewDialog(nil, nil, d.panel, []*DialogButtonInfo{NewCancelButtonInfo(), NewOKButtonInfo()})
@@ -150,6 +155,7 @@ func (d *wellDialog) addColorSelector(parent *Panel) {
 		color = *inkColor
 	default:
 	}
+	d.hue, d.saturation, d.brightness = color.HSB()
 
 	left := NewPanel()
 	left.SetLayout(&FlexLayout{
@@ -189,12 +195,12 @@ func (d *wellDialog) addColorSelector(parent *Panel) {
 	})
 	parent.AddChild(right)
 
-	d.hueField = d.addHueField(right, color)
-	d.saturationField = d.addPercentageField(right, i18n.Text("Saturation:"), color.Saturation(), func(value float32, color Color) Color {
-		return color.SetSaturation(value)
+	d.hueField = d.addHueField(right)
+	d.saturationField = d.addPercentageField(right, i18n.Text("Saturation:"), d.saturation, func(value float32) {
+		d.saturation = value
 	})
-	d.brightnessField = d.addPercentageField(right, i18n.Text("Brightness:"), color.Brightness(), func(value float32, color Color) Color {
-		return color.SetBrightness(value)
+	d.brightnessField = d.addPercentageField(right, i18n.Text("Brightness:"), d.brightness, func(value float32) {
+		d.brightness = value
 	})
 
 	bottom := NewPanel()
@@ -214,6 +220,27 @@ func (d *wellDialog) addColorSelector(parent *Panel) {
 	d.cssField = d.addCSSField(bottom, color)
 }
 
+func (d *wellDialog) addGradientSelector(parent *Panel) {
+	editor := NewGradientEditor()
+	if gradient, ok := d.ink.(*Gradient); ok {
+		editor.SetGradient(gradient)
+	}
+	if d.well.Mask&^GradientWellMask != 0 {
+		editor.SetBorder(NewEmptyBorder(Insets{Top: 2 * StdHSpacing}))
+	}
+	editor.SetLayoutData(&FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	editor.GradientChangedCallback = func() {
+		d.ink = editor.Gradient()
+		d.dialog.Window().MarkForRedraw()
+	}
+	parent.AddChild(editor)
+}
+
 func (d *wellDialog) addChannelField(parent *Panel, title string, value int, adjuster func(value int, color Color) Color) *Field {
 	l := NewLabel()
 	l.SetTitle(title)
@@ -232,7 +259,7 @@ func (d *wellDialog) addChannelField(parent *Panel, title string, value int, adj
 		VAlign: align.Middle,
 		HGrab:  true,
 	})
-	field.ValidateCallback = func() bool {
+	field.ValidateWithMessageCallback = func() (valid bool, message string) {
 		text := strings.TrimSpace(field.Text())
 		if text == "" {
 			text = "0"
@@ -241,13 +268,13 @@ func (d *wellDialog) addChannelField(parent *Panel, title string, value int, adj
 		if strings.HasSuffix(text, "%") {
 			percentage, err := extractColorPercentage(text)
 			if err != nil {
-				return false
+				return false, i18n.Text("Must be 0-255 or 0-100%")
 			}
 			v = clamp0To1AndScale255(percentage)
 		} else {
 			var err error
 			if v, err = strconv.Atoi(text); err != nil || v < 0 || v > 255 {
-				return false
+				return false, i18n.Text("Must be 0-255 or 0-100%")
 			}
 		}
 		if !d.syncing {
@@ -255,10 +282,14 @@ func (d *wellDialog) addChannelField(parent *Panel, title string, value int, adj
 			if !ok {
 				color = Black
 			}
-			d.ink = adjuster(v, color)
+			adjusted := adjuster(v, color)
+			if adjusted.SetAlpha(255) != color.SetAlpha(255) {
+				d.updateHSBFrom(adjusted)
+			}
+			d.ink = adjusted
 			d.sync()
 		}
-		return true
+		return true, ""
 	}
 	parent.AddChild(field)
 	l = NewLabel()
@@ -271,7 +302,7 @@ func (d *wellDialog) addChannelField(parent *Panel, title string, value int, adj
 	return field
 }
 
-func (d *wellDialog) addHueField(parent *Panel, color Color) *Field {
+func (d *wellDialog) addHueField(parent *Panel) *Field {
 	l := NewLabel()
 	l.SetTitle(i18n.Text("Hue:"))
 	l.HAlign = align.End
@@ -281,7 +312,7 @@ func (d *wellDialog) addHueField(parent *Panel, color Color) *Field {
 	})
 	parent.AddChild(l)
 	field := NewField()
-	field.SetText(strconv.Itoa(int(color.Hue()*360 + 0.5)))
+	field.SetText(strconv.Itoa(int(d.hue*360 + 0.5)))
 	field.Watermark = "0"
 	field.SetMinimumTextWidthUsing("360", "100%")
 	field.SetLayoutData(&FlexLayoutData{
@@ -289,7 +320,7 @@ func (d *wellDialog) addHueField(parent *Panel, color Color) *Field {
 		VAlign: align.Middle,
 		HGrab:  true,
 	})
-	field.ValidateCallback = func() bool {
+	field.ValidateWithMessageCallback = func() (valid bool, message string) {
 		text := strings.TrimSpace(field.Text())
 		if text == "" {
 			text = "0"
@@ -298,24 +329,21 @@ func (d *wellDialog) addHueField(parent *Panel, color Color) *Field {
 		if strings.HasSuffix(text, "%") {
 			var err error
 			if percentage, err = extractColorPercentage(text); err != nil {
-				return false
+				return false, i18n.Text("Must be 0-360 or 0-100%")
 			}
 		} else {
 			v, err := strconv.Atoi(text)
 			if err != nil || v < 0 || v > 360 {
-				return false
+				return false, i18n.Text("Must be 0-360 or 0-100%")
 			}
 			percentage = float32(v) / 360
 		}
 		if !d.syncing {
-			c, ok := d.ink.(Color)
-			if !ok {
-				c = Black
-			}
-			d.ink = c.SetHue(percentage)
+			d.hue = percentage
+			d.ink = d.colorFromHSB()
 			d.sync()
 		}
-		return true
+		return true, ""
 	}
 	parent.AddChild(field)
 	l = NewLabel()
@@ -328,7 +356,7 @@ func (d *wellDialog) addHueField(parent *Panel, color Color) *Field {
 	return field
 }
 
-func (d *wellDialog) addPercentageField(parent *Panel, title string, value float32, adjuster func(value float32, color Color) Color) *Field {
+func (d *wellDialog) addPercentageField(parent *Panel, title string, value float32, adjuster func(value float32)) *Field {
 	l := NewLabel()
 	l.SetTitle(title)
 	l.HAlign = align.End
@@ -346,7 +374,7 @@ func (d *wellDialog) addPercentageField(parent *Panel, title string, value float
 		VAlign: align.Middle,
 		HGrab:  true,
 	})
-	field.ValidateCallback = func() bool {
+	field.ValidateWithMessageCallback = func() (valid bool, message string) {
 		text := strings.TrimSpace(field.Text())
 		if text == "" {
 			text = "0%"
@@ -356,17 +384,14 @@ func (d *wellDialog) addPercentageField(parent *Panel, title string, value float
 		}
 		percentage, err := extractColorPercentage(text)
 		if err != nil {
-			return false
+			return false, i18n.Text("Must be 0-100%")
 		}
 		if !d.syncing {
-			color, ok := d.ink.(Color)
-			if !ok {
-				color = Black
-			}
-			d.ink = adjuster(percentage, color)
+			adjuster(percentage)
+			d.ink = d.colorFromHSB()
 			d.sync()
 		}
-		return true
+		return true, ""
 	}
 	parent.AddChild(field)
 	l = NewLabel()
@@ -391,21 +416,23 @@ func (d *wellDialog) addCSSField(parent *Panel, color Color) *Field {
 	field := NewField()
 	field.SetText(color.String())
 	field.Watermark = "CSS"
+	field.CompletionProvider = func(_ string) []string { return ColorNames() }
 	field.SetLayoutData(&FlexLayoutData{
 		HAlign: align.Fill,
 		VAlign: align.Middle,
 		HGrab:  true,
 	})
-	field.ValidateCallback = func() bool {
+	field.ValidateWithMessageCallback = func() (valid bool, message string) {
 		if !d.syncing {
 			adjustedColor, err := ColorDecode(field.Text())
 			if err != nil {
-				return false
+				return false, i18n.Text("Not a valid CSS color")
 			}
+			d.updateHSBFrom(adjustedColor)
 			d.ink = adjustedColor
 			d.sync()
 		}
-		return true
+		return true, ""
 	}
 	parent.AddChild(field)
 	for _, txt := range []string{
@@ -438,15 +465,37 @@ func (d *wellDialog) sync() {
 		d.syncText(d.greenField, strconv.Itoa(t.Green()))
 		d.syncText(d.blueField, strconv.Itoa(t.Blue()))
 		d.syncText(d.alphaField, strconv.Itoa(t.Alpha()))
-		d.syncText(d.hueField, strconv.Itoa(int(t.Hue()*360+0.5)))
-		d.syncText(d.saturationField, strconv.Itoa(int(t.Saturation()*100+0.5))+"%")
-		d.syncText(d.brightnessField, strconv.Itoa(int(t.Brightness()*100+0.5))+"%")
+		d.syncText(d.hueField, strconv.Itoa(int(d.hue*360+0.5)))
+		d.syncText(d.saturationField, strconv.Itoa(int(d.saturation*100+0.5))+"%")
+		d.syncText(d.brightnessField, strconv.Itoa(int(d.brightness*100+0.5))+"%")
 		d.syncText(d.cssField, t.String())
 	default:
 	}
 	d.syncing = false
 }
 
+// updateHSBFrom updates the authoritative HSB values from the color. Components that are undefined for the color, such
+// as the hue of a gray or the saturation of black, retain their previous values so that they aren't lost.
+func (d *wellDialog) updateHSBFrom(color Color) {
+	h, s, b := color.HSB()
+	if b != 0 {
+		if s != 0 {
+			d.hue = h
+		}
+		d.saturation = s
+	}
+	d.brightness = b
+}
+
+// colorFromHSB returns a color created from the authoritative HSB values and the alpha of the current color.
+func (d *wellDialog) colorFromHSB() Color {
+	alpha := float32(1)
+	if c, ok := d.ink.(Color); ok {
+		alpha = c.AlphaIntensity()
+	}
+	return HSBA(d.hue, d.saturation, d.brightness, alpha)
+}
+
 func (d *wellDialog) syncText(field *Field, text string) {
 	if !field.Focused() {
 		field.SetText(text)
@@ -474,11 +523,22 @@ func (d *wellDialog) addPreviewBlock(parent *Panel, title string, spaceBefore fl
 	preview.SetLayoutData(&FlexLayoutData{
 		SizeHint: Size{Width: 64, Height: 64},
 	})
+	var thumbSource, thumb *Image
 	preview.DrawCallback = func(canvas *Canvas, _ Rect) {
 		r := preview.ContentRect(false)
+		DrawCheckerboard(canvas, r, 8, White, LightGray)
 		ink := inkRetriever()
 		if pattern, ok := ink.(*Pattern); ok {
-			canvas.DrawImageInRect(pattern.Image, r, nil, nil)
+			if pattern.Image != thumbSource {
+				thumbSource = pattern.Image
+				var err error
+				// Allow for high-density displays
+				if thumb, err = pattern.Image.Thumbnail(int(xmath.Ceil(max(r.Width, r.Height)*2)), nil); err != nil {
+					errs.Log(err)
+					thumb = pattern.Image
+				}
+			}
+			canvas.DrawImageInRect(thumb, r, nil, nil)
 		} else {
 			canvas.DrawRect(r, ink.Paint(canvas, r, paintstyle.Fill))
 		}
@@ -10,6 +10,7 @@
 package unison
 
 import (
+	"cmp"
 	"fmt"
 	"image"
 	"slices"
@@ -56,6 +57,10 @@ type Window struct {
 	MovedCallback func()
 	// ResizedCallback is called when the window is resized.
 	ResizedCallback func()
+	// ScaleChangedCallback is called when the scale of the window's backing store changes, such as when it is moved
+	// between displays with differing resolutions. Use this to regenerate any cached images that were rasterized at the
+	// old scale.
+	ScaleChangedCallback func(oldScale, newScale float32)
 	// AllowCloseCallback is called when the user has requested that the window be closed. Return true to permit it,
 	// false to cancel the operation. Defaults to always returning true.
 	AllowCloseCallback func() bool
@@ -86,6 +91,7 @@ type Window struct {
 	modalResultCode        int
 	lastButton             int
 	lastButtonCount        int
+	lastBackingScale       float32
 	lastContentRect        Rect
 	firstButtonLocation    Point
 	dragDataLocation       Point
@@ -197,7 +203,7 @@ func NewWindow(title string, options ...WindowOption) (*Window, error) {
 	w := &Window{
 		title:      title,
 		titleIcons: DefaultTitleIcons,
-		surface:    &surface{},
+		surface:    &surface{software: softwareRendering},
 	}
 	for _, option := range options {
 		if err := option(w); err != nil {
@@ -233,6 +239,9 @@ func NewWindow(title string, options ...WindowOption) (*Window, error) {
 			w.resized()
 		}
 	})
+	w.wnd.SetContentScaleCallback(func(_ *glfw.Window, x, _ float32) {
+		w.scaleChanged(x)
+	})
 	w.wnd.SetCloseCallback(func(_ *glfw.Window) {
 		if w.okToProcess() {
 			w.AttemptClose()
@@ -282,6 +291,7 @@ func NewWindow(title string, options ...WindowOption) (*Window, error) {
 		}
 	})
 	w.valid = true
+	w.lastBackingScale, _ = w.BackingScale()
 	windowList = append(windowList, w)
 	windowMap[w.wnd] = w
 	w.root = newRootPanel(w)
@@ -376,6 +386,18 @@ func (w *Window) resized() {
 	}
 }
 
+func (w *Window) scaleChanged(scale float32) {
+	if scale == w.lastBackingScale {
+		return
+	}
+	oldScale := w.lastBackingScale
+	w.lastBackingScale = scale
+	w.MarkForRedraw()
+	if w.ScaleChangedCallback != nil {
+		toolbox.Call(func() { w.ScaleChangedCallback(oldScale, scale) })
+	}
+}
+
 func (w *Window) gainedFocus() {
 	w.focused = true
 	if len(windowList) != 0 && windowList[0] != w {
@@ -697,6 +719,7 @@ func (w *Window) SetFocus(target Paneler) {
 				}
 			}
 			w.notifyOfFocusChangeInHierarchy(oldFocus, newFocus)
+			notifyAccessibilityFocus(newFocus)
 		}
 	}
 }
@@ -733,7 +756,11 @@ func (w *Window) FocusNext() {
 		if current == nil {
 			current = w.root.contentPanel
 		}
-		i, focusables := collectFocusables(w.root.contentPanel, current, nil)
+		if next := current.nextFocusTarget; next != nil && next.Focusable() && next.Window() == w {
+			w.SetFocus(next)
+			return
+		}
+		i, focusables := orderedFocusables(w.root.contentPanel, current)
 		if len(focusables) > 0 {
 			i++
 			if i >= len(focusables) {
@@ -752,7 +779,13 @@ func (w *Window) FocusPrevious() {
 		if current == nil {
 			current = w.root.contentPanel
 		}
-		i, focusables := collectFocusables(w.root.contentPanel, current, nil)
+		i, focusables := orderedFocusables(w.root.contentPanel, current)
+		for _, one := range focusables {
+			if one.nextFocusTarget != nil && one.nextFocusTarget.Is(current) {
+				w.SetFocus(one)
+				return
+			}
+		}
 		if len(focusables) > 0 {
 			i--
 			if i < 0 {
@@ -764,6 +797,29 @@ func (w *Window) FocusPrevious() {
 	}
 }
 
+// orderedFocusables returns the focusable panels within root in traversal order, along with the index of target
+// within them, or -1. Panels with a positive tab index come first, in ascending order, followed by the remaining panels
+// in hierarchy order.
+func orderedFocusables(root, target *Panel) (match int, result []*Panel) {
+	match, result = collectFocusables(root, target, nil)
+	if !slices.ContainsFunc(result, func(p *Panel) bool { return p.tabIndex > 0 }) {
+		return match, result
+	}
+	slices.SortStableFunc(result, func(a, b *Panel) int {
+		switch {
+		case a.tabIndex > 0 && b.tabIndex > 0:
+			return cmp.Compare(a.tabIndex, b.tabIndex)
+		case a.tabIndex > 0:
+			return -1
+		case b.tabIndex > 0:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return slices.IndexFunc(result, func(p *Panel) bool { return p.Is(target) }), result
+}
+
 func collectFocusables(current, target *Panel, focusables []*Panel) (match int, result []*Panel) {
 	match = -1
 	if current.Focusable() {
@@ -861,6 +917,9 @@ func (w *Window) Draw(c *Canvas) {
 			w.root.ValidateLayout()
 			c.DrawPaint(ThemeSurface.Paint(c, w.LocalContentRect(), paintstyle.Fill))
 			w.root.Draw(c, w.LocalContentRect())
+			if ShowFocusRing && w.focus != nil && len(w.root.openMenuPanels) == 0 {
+				DefaultFocusRingTheme.DrawFocusRing(c, w.focus)
+			}
 			if w.InDrag() {
 				c.Save()
 				c.Translate(w.dragDataLocation.X+w.dragData.Offset.X, w.dragDataLocation.Y+w.dragData.Offset.Y)
@@ -893,6 +952,9 @@ func (w *Window) draw() {
 		w.Draw(c)
 		c.Restore()
 		c.Flush()
+		if err = w.surface.present(); err != nil {
+			errs.Log(err)
+		}
 		w.lastDrawDuration = time.Since(start)
 		w.wnd.SwapBuffers()
 	}
@@ -1194,6 +1256,9 @@ func (w *Window) keyDown(keyCode KeyCode, mod Modifiers, repeat bool) {
 	}
 	w.ClearTooltip()
 	w.lastKeyDownPanel = nil
+	if w.handleKeyBindingHooks(keyCode, mod) {
+		return
+	}
 	if focus := w.Focus(); focus != nil {
 		panel := focus
 		w.lastKeyDownPanel = panel
@@ -1218,6 +1283,19 @@ func (w *Window) keyDown(keyCode KeyCode, mod Modifiers, repeat bool) {
 	}
 }
 
+// handleKeyBindingHooks gives the key binding hooks of the panels currently within the window the chance to handle a
+// key down event ahead of the focused panel. The panels are found at the time of the event, so a hook is active for
+// exactly as long as its panel is within the window.
+func (w *Window) handleKeyBindingHooks(keyCode KeyCode, mod Modifiers) bool {
+	if w.root.contentPanel != nil {
+		if panel := w.root.contentPanel.handleKeyBindingHooks(keyCode, mod); panel != nil {
+			w.lastKeyDownPanel = panel
+			return true
+		}
+	}
+	return false
+}
+
 func (w *Window) keyUp(keyCode KeyCode, mod Modifiers) {
 	if w.root.preKeyUp(w, keyCode, mod) {
 		return
@@ -0,0 +1,112 @@
+// Copyright (c) 2021-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package unison
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	_ WordBreaker = StandardWordBreaker{}
+	_ WordBreaker = CJKWordBreaker{}
+)
+
+// DefaultWordBreaker is the WordBreaker used by Text.BreakToWidth and by Fields that have not been given one of their
+// own.
+var DefaultWordBreaker WordBreaker = StandardWordBreaker{}
+
+// WordBreaker determines how text is divided into words, both for selecting words and for wrapping lines.
+type WordBreaker interface {
+	// IsWordPart returns true if the rune at the index is part of a word.
+	IsWordPart(runes []rune, index int) bool
+	// IsWordBoundary returns true if a word ends between the rune at index-1 and the rune at the index, even though
+	// both are word parts.
+	IsWordBoundary(runes []rune, index int) bool
+	// CanBreakAfter returns true if a line may be wrapped after the rune at the index.
+	CanBreakAfter(runes []rune, index int) bool
+}
+
+// StandardWordBreaker treats runs of letters, digits and underscores as words and only wraps lines after whitespace
+// and slashes.
+type StandardWordBreaker struct{}
+
+// IsWordPart implements WordBreaker.
+func (StandardWordBreaker) IsWordPart(runes []rune, index int) bool {
+	r := runes[index]
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// IsWordBoundary implements WordBreaker.
+func (StandardWordBreaker) IsWordBoundary(_ []rune, _ int) bool {
+	return false
+}
+
+// CanBreakAfter implements WordBreaker.
+func (StandardWordBreaker) CanBreakAfter(runes []rune, index int) bool {
+	return isWordBreak(runes[index])
+}
+
+// CJKWordBreaker extends the StandardWordBreaker behavior by treating each Han ideograph and Hiragana character as a
+// word of its own, keeping runs of Katakana together, and allowing lines to wrap between any of them, while honoring
+// the common rules that prevent closing punctuation from starting a line and opening punctuation from ending one. It
+// also allows lines to wrap after a hyphen that joins two words.
+type CJKWordBreaker struct{}
+
+// IsWordPart implements WordBreaker.
+func (CJKWordBreaker) IsWordPart(runes []rune, index int) bool {
+	return StandardWordBreaker{}.IsWordPart(runes, index)
+}
+
+// IsWordBoundary implements WordBreaker.
+func (CJKWordBreaker) IsWordBoundary(runes []rune, index int) bool {
+	if index <= 0 || index >= len(runes) {
+		return false
+	}
+	prev := runes[index-1]
+	r := runes[index]
+	if isCJKCharWord(prev) || isCJKCharWord(r) {
+		return true
+	}
+	return unicode.Is(unicode.Katakana, prev) != unicode.Is(unicode.Katakana, r)
+}
+
+// CanBreakAfter implements WordBreaker.
+func (CJKWordBreaker) CanBreakAfter(runes []rune, index int) bool {
+	r := runes[index]
+	if strings.ContainsRune(cjkNoBreakAfter, r) {
+		return false
+	}
+	if index+1 >= len(runes) {
+		return true
+	}
+	next := runes[index+1]
+	if strings.ContainsRune(cjkNoBreakBefore, next) {
+		return false
+	}
+	if isWordBreak(r) || isCJK(r) || isCJK(next) {
+		return true
+	}
+	return r == '-' && index > 0 && unicode.IsLetter(runes[index-1]) && unicode.IsLetter(next)
+}
+
+const (
+	cjkNoBreakBefore = ")]}｝〕〉》」』】〙〗〟’”｠»、。，．・：；？！ゝゞーァィゥェォッャュョヮヵヶぁぃぅぇぉっゃゅょゎゕゖ々〻‐゠–〜～）］"
+	cjkNoBreakAfter  = "([{｛〔〈《「『【〘〖〝‘“｟«（［"
+)
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
+
+func isCJKCharWord(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana)
+}